@@ -0,0 +1,118 @@
+package myasthurts
+
+// Alias represents a type alias declaration. Ex: `type Time = time.Time`.
+//
+// Aliases do not define a new type, so methods are always looked up (and
+// added) on the aliased type.
+type Alias struct {
+	BaseType
+	Doc Doc
+	// Target is the aliased type.
+	Target   RefType
+	Position Position
+	// Platforms where the alias exists, see Platform.
+	Platforms []Platform
+}
+
+// NewAlias creates a new Alias with no target defined.
+func NewAlias(pkg *Package, name string) *Alias {
+	return &Alias{
+		BaseType: *NewBaseType(pkg, name),
+	}
+}
+
+// Aliased returns the Type the alias refers to, following chained aliases. It
+// returns nil if the target is not resolved.
+func (a *Alias) Aliased() Type {
+	if a.Target == nil {
+		return nil
+	}
+	return Unalias(a.Target).Type()
+}
+
+// aliasedLocked is Aliased for callers holding the parse lock of the
+// environment, see typeLocked.
+func (a *Alias) aliasedLocked() Type {
+	if a.Target == nil {
+		return nil
+	}
+	return typeLocked(unaliasLocked(a.Target))
+}
+
+// Methods returns the methods of the aliased type.
+func (a *Alias) Methods() []*TypeMethod {
+	if t := a.Aliased(); t != nil {
+		return t.Methods()
+	}
+	return a.BaseType.Methods()
+}
+
+// MethodsMap returns the methods of the aliased type indexed by name.
+func (a *Alias) MethodsMap() map[string]*TypeMethod {
+	t := a.Aliased()
+	if t == nil {
+		return a.BaseType.MethodsMap()
+	}
+	if mm, ok := t.(interface {
+		MethodsMap() map[string]*TypeMethod
+	}); ok {
+		return mm.MethodsMap()
+	}
+	methodsMap := make(map[string]*TypeMethod)
+	for _, m := range t.Methods() {
+		methodsMap[m.Descriptor.Name()] = m
+	}
+	return methodsMap
+}
+
+// AddMethod adds the method to the aliased type. If the target is not
+// resolved, the method is kept by the alias itself.
+func (a *Alias) AddMethod(method *TypeMethod) {
+	if t := a.aliasedLocked(); t != nil {
+		t.AddMethod(method)
+		return
+	}
+	a.BaseType.AddMethod(method)
+}
+
+// RemoveMethod removes the method from the aliased type, or from the alias
+// itself if the method was added while the target was not resolved.
+func (a *Alias) RemoveMethod(descriptor *MethodDescriptor) bool {
+	if a.BaseType.RemoveMethod(descriptor) {
+		return true
+	}
+	if t, ok := a.aliasedLocked().(interface {
+		RemoveMethod(*MethodDescriptor) bool
+	}); ok {
+		return t.RemoveMethod(descriptor)
+	}
+	return false
+}
+
+// Unalias follows the RefType while it refers to an Alias, returning the
+// RefType of the actual type. Composite RefTypes (Ex: `*T`, `[]T`) are
+// returned as they are.
+func Unalias(ref RefType) RefType {
+	return unalias(ref, RefType.Type)
+}
+
+// unaliasLocked is Unalias for callers holding the parse lock of the
+// environment, see typeLocked.
+func unaliasLocked(ref RefType) RefType {
+	return unalias(ref, typeLocked)
+}
+
+func unalias(ref RefType, typeOf func(RefType) Type) RefType {
+	// The limit protects against invalid cyclic aliases.
+	for i := 0; i < 100 && ref != nil; i++ {
+		if _, ok := ref.(*BaseRefType); !ok {
+			return ref
+		}
+		alias, ok := typeOf(ref).(*Alias)
+		if !ok || alias.Target == nil {
+			return ref
+		}
+		ref = alias.Target
+	}
+	return ref
+}
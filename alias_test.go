@@ -0,0 +1,96 @@
+package myasthurts_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	myasthurts "github.com/jamillosantos/go-my-ast-hurts"
+)
+
+var _ = Describe("Alias", func() {
+	Describe("Parse", func() {
+		It("should parse type aliases", func() {
+			env, err := myasthurts.NewEnvironment()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(env.ParseFile(newDataPackageContext(env), "data/models18.sample.go")).To(Succeed())
+
+			pkg, ok := env.PackageByImportPath("data")
+			Expect(ok).To(BeTrue())
+
+			Expect(pkg.Aliases).To(HaveLen(3))
+			Expect(pkg.NamedTypes).To(BeEmpty())
+			Expect(pkg.Structs).To(HaveLen(1))
+
+			name, ok := pkg.AliasByName("Name")
+			Expect(ok).To(BeTrue())
+			Expect(name.Doc.Comments).To(HaveLen(1))
+			Expect(name.Target.Name()).To(Equal("string"))
+			Expect(name.Target.Pkg()).To(Equal(env.BuiltIn))
+
+			user, ok := pkg.StructByName("User")
+			Expect(ok).To(BeTrue())
+
+			person, ok := pkg.AliasByName("Person")
+			Expect(ok).To(BeTrue())
+			Expect(person.Aliased()).To(Equal(user))
+
+			t, ok := pkg.AliasByName("Time")
+			Expect(ok).To(BeTrue())
+			Expect(t.Target.Name()).To(Equal("Time"))
+			Expect(t.Target.Pkg().ImportPath).To(Equal("time"))
+		})
+
+		It("should parse builtin aliases", func() {
+			env, err := myasthurts.NewEnvironment()
+			Expect(err).ToNot(HaveOccurred())
+
+			byteRef, ok := env.BuiltIn.RefTypeByName("byte")
+			Expect(ok).To(BeTrue())
+			uint8Ref, ok := env.BuiltIn.RefTypeByName("uint8")
+			Expect(ok).To(BeTrue())
+
+			Expect(myasthurts.Unalias(byteRef)).To(Equal(uint8Ref))
+		})
+	})
+
+	Describe("Methods", func() {
+		It("should add methods declared on the alias to the aliased type", func() {
+			env, err := myasthurts.NewEnvironment()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(env.ParseFile(newDataPackageContext(env), "data/models18.sample.go")).To(Succeed())
+
+			pkg, ok := env.PackageByImportPath("data")
+			Expect(ok).To(BeTrue())
+
+			user, ok := pkg.StructByName("User")
+			Expect(ok).To(BeTrue())
+			Expect(user.MethodsMap()).To(HaveLen(2))
+			Expect(user.MethodsMap()).To(HaveKey("SetName"))
+			Expect(user.MethodsMap()).To(HaveKey("GetName"))
+
+			person, ok := pkg.AliasByName("Person")
+			Expect(ok).To(BeTrue())
+			Expect(person.Methods()).To(HaveLen(2))
+			Expect(person.MethodsMap()).To(HaveKey("GetName"))
+		})
+
+		It("should follow aliases when checking implementations", func() {
+			env, err := myasthurts.NewEnvironment()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(env.ParseFile(newDataPackageContext(env), "data/models18.sample.go")).To(Succeed())
+
+			pkg, ok := env.PackageByImportPath("data")
+			Expect(ok).To(BeTrue())
+
+			user, ok := pkg.StructByName("User")
+			Expect(ok).To(BeTrue())
+			hasName, ok := pkg.InterfaceByName("HasName")
+			Expect(ok).To(BeTrue())
+
+			Expect(user.ImplementsPtr(hasName)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,87 @@
+package myasthurts_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	myasthurts "github.com/jamillosantos/go-my-ast-hurts"
+)
+
+var _ = Describe("Arrays and slices", func() {
+	var header *myasthurts.Struct
+
+	BeforeEach(func() {
+		env, err := myasthurts.NewEnvironment()
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = env.Parse("crypto/sha256")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(env.ParseFile(newDataPackageContext(env), "data/models26.sample.go")).To(Succeed())
+
+		pkg, ok := env.PackageByImportPath("data")
+		Expect(ok).To(BeTrue())
+		header, ok = pkg.StructByName("Header")
+		Expect(ok).To(BeTrue())
+	})
+
+	array := func(ref myasthurts.RefType) *myasthurts.ArrayRefType {
+		var arrayRefType *myasthurts.ArrayRefType
+		Expect(ref).To(BeAssignableToTypeOf(arrayRefType))
+		return ref.(*myasthurts.ArrayRefType)
+	}
+
+	It("should parse arrays with literal lengths", func() {
+		magic := array(header.Fields[0].RefType)
+		Expect(magic.LenExpr).To(Equal("4"))
+		Expect(magic.Len).To(BeEquivalentTo(4))
+		Expect(magic.Name()).To(Equal("byte"))
+	})
+
+	It("should evaluate lengths referring to constants", func() {
+		payload := array(header.Fields[1].RefType)
+		Expect(payload.LenExpr).To(Equal("MaxItems"))
+		Expect(payload.Len).To(BeEquivalentTo(8))
+
+		checksum := array(header.Fields[2].RefType)
+		Expect(checksum.LenExpr).To(Equal("sha256.Size"))
+		Expect(checksum.Len).To(BeEquivalentTo(32))
+	})
+
+	It("should parse multidimensional arrays", func() {
+		matrix := array(header.Fields[3].RefType)
+		Expect(matrix.LenExpr).To(Equal("Rows * 2"))
+		Expect(matrix.Len).To(BeEquivalentTo(6))
+		Expect(array(matrix.RefType).Len).To(BeEquivalentTo(3))
+	})
+
+	It("should fail for invalid lengths", func() {
+		env, err := myasthurts.NewEnvironment()
+		Expect(err).ToNot(HaveOccurred())
+
+		err = env.ParseFile(newDataPackageContext(env), "data/models34.sample.go")
+		Expect(errors.Is(err, myasthurts.ErrInvalidArrayLen)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("Rows % 1.5"))
+	})
+
+	It("should fail for negative lengths", func() {
+		env, err := myasthurts.NewEnvironment()
+		Expect(err).ToNot(HaveOccurred())
+
+		err = env.ParseFile(newDataPackageContext(env), "data/models37.sample.go")
+		Expect(errors.Is(err, myasthurts.ErrInvalidArrayLen)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("Low - High"))
+	})
+
+	It("should parse slices", func() {
+		var sliceRefType *myasthurts.SliceRefType
+		Expect(header.Fields[4].RefType).To(BeAssignableToTypeOf(sliceRefType))
+		Expect(header.Fields[4].RefType.Name()).To(Equal("string"))
+	})
+
+	It("should compare arrays by length", func() {
+		Expect(myasthurts.Identical(header.Fields[1].RefType, header.Fields[5].RefType)).To(BeTrue())
+		Expect(myasthurts.Identical(header.Fields[0].RefType, header.Fields[1].RefType)).To(BeFalse())
+	})
+})
@@ -0,0 +1,58 @@
+package myasthurts_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	myasthurts "github.com/jamillosantos/go-my-ast-hurts"
+)
+
+var _ = Describe("Channels", func() {
+	var pkg *myasthurts.Package
+
+	BeforeEach(func() {
+		env, err := myasthurts.NewEnvironment()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(env.ParseFile(newDataPackageContext(env), "data/models27.sample.go")).To(Succeed())
+
+		var ok bool
+		pkg, ok = env.PackageByImportPath("data")
+		Expect(ok).To(BeTrue())
+	})
+
+	chanDir := func(ref myasthurts.RefType) myasthurts.ChanDir {
+		var chanRefType *myasthurts.ChanRefType
+		Expect(ref).To(BeAssignableToTypeOf(chanRefType))
+		return ref.(*myasthurts.ChanRefType).Dir
+	}
+
+	It("should parse the channel direction", func() {
+		pool, ok := pkg.StructByName("Pool")
+		Expect(ok).To(BeTrue())
+		Expect(chanDir(pool.Fields[0].RefType)).To(Equal(myasthurts.ChanBoth))
+		Expect(chanDir(pool.Fields[1].RefType)).To(Equal(myasthurts.ChanSend))
+		Expect(chanDir(pool.Fields[2].RefType)).To(Equal(myasthurts.ChanRecv))
+		Expect(pool.Fields[2].RefType.Name()).To(Equal("bool"))
+	})
+
+	It("should consider the direction on identity checks", func() {
+		pool, ok := pkg.StructByName("Pool")
+		Expect(ok).To(BeTrue())
+		run := pool.MethodsMap()["Run"].Descriptor
+		Expect(myasthurts.Identical(run.Arguments[1].Type, pool.Fields[1].RefType)).To(BeTrue())
+		Expect(myasthurts.Identical(run.Arguments[0].Type, pool.Fields[0].RefType)).To(BeFalse())
+	})
+
+	It("should consider the direction on interface implementation", func() {
+		worker, ok := pkg.InterfaceByName("Worker")
+		Expect(ok).To(BeTrue())
+
+		pool, ok := pkg.StructByName("Pool")
+		Expect(ok).To(BeTrue())
+		Expect(pool.ImplementsPtr(worker)).To(BeTrue())
+
+		broken, ok := pkg.StructByName("BrokenPool")
+		Expect(ok).To(BeTrue())
+		Expect(broken.ImplementsPtr(worker)).To(BeFalse())
+	})
+})
@@ -0,0 +1,219 @@
+package myasthurts_test
+
+import (
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	myasthurts "github.com/jamillosantos/go-my-ast-hurts"
+)
+
+var _ = Describe("Concurrency", func() {
+	const treePath = "github.com/jamillosantos/go-my-ast-hurts/data/tree"
+
+	var env *myasthurts.Environment
+
+	BeforeEach(func() {
+		var err error
+		env, err = myasthurts.NewEnvironment()
+		Expect(err).ToNot(HaveOccurred())
+		env.Config.Workers = 4
+	})
+
+	It("should parse packages from many goroutines", func() {
+		dirs := []string{
+			"./data/tree",
+			"./data/tree/models",
+			"./data/tree/models/admin",
+			"./data/tree/internal/empty/deep",
+			"./data/platforms",
+			"./data/tests",
+		}
+
+		var wg sync.WaitGroup
+		packages := make([]*myasthurts.Package, len(dirs)*2)
+		errs := make([]error, len(dirs)*2)
+		for i := range packages {
+			wg.Add(1)
+			go func(i int) {
+				defer GinkgoRecover()
+				defer wg.Done()
+				packages[i], errs[i] = env.ParseDir(dirs[i%len(dirs)])
+				// Readers run while other goroutines parse.
+				_, _ = env.PackageByImportPath(treePath)
+				_, _ = packages[i].RefTypeByName("Root")
+			}(i)
+		}
+		wg.Wait()
+
+		for i := range packages {
+			Expect(errs[i]).ToNot(HaveOccurred())
+			Expect(packages[i].Explored).To(BeTrue())
+			// The same package is returned for the same directory.
+			Expect(packages[i]).To(BeIdenticalTo(packages[i%len(dirs)]))
+		}
+
+		models, ok := env.PackageByImportPath(treePath + "/models")
+		Expect(ok).To(BeTrue())
+		user, ok := models.StructByName("User")
+		Expect(ok).To(BeTrue())
+		root, ok := env.PackageByImportPath(treePath)
+		Expect(ok).To(BeTrue())
+		Expect(user.Fields[0].RefType.Pkg()).To(BeIdenticalTo(root))
+	})
+
+	It("should resolve types while other goroutines parse", func() {
+		env.Config.Resolve = true
+		Expect(env.ParseFile(newDataPackageContext(env), "data/models33.sample.go")).To(Succeed())
+		pkg, ok := env.PackageByImportPath("data")
+		Expect(ok).To(BeTrue())
+		entry, ok := pkg.StructByName("Entry")
+		Expect(ok).To(BeTrue())
+
+		var (
+			wg  sync.WaitGroup
+			t   myasthurts.Type
+			err error
+		)
+		wg.Add(2)
+		go func() {
+			defer GinkgoRecover()
+			defer wg.Done()
+			// time is not explored, so it is parsed on this access.
+			t = entry.Fields[0].RefType.Type()
+		}()
+		go func() {
+			defer GinkgoRecover()
+			defer wg.Done()
+			_, err = env.ParseDir("./data/tree/models/admin")
+		}()
+		wg.Wait()
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(t).ToNot(BeNil())
+		Expect(t.Name()).To(Equal("Time"))
+	})
+
+	It("should publish the types when their packages are parsed", func() {
+		Expect(env.ParseFile(newDataPackageContext(env), "data/models33.sample.go")).To(Succeed())
+		pkg, ok := env.PackageByImportPath("data")
+		Expect(ok).To(BeTrue())
+		entry, ok := pkg.StructByName("Entry")
+		Expect(ok).To(BeTrue())
+		ref := entry.Fields[0].RefType
+
+		var wg sync.WaitGroup
+		done := make(chan struct{})
+		wg.Add(1)
+		go func() {
+			defer GinkgoRecover()
+			defer wg.Done()
+			// Reads the models of time while it is parsed.
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				if t, ok := ref.Type().(*myasthurts.Struct); ok {
+					_ = t.Methods()
+					_ = t.MethodsMap()
+				}
+				_, _ = ref.Pkg().StructByName("Time")
+				_ = ref.Pkg().Enums()
+			}
+		}()
+		_, err := env.Parse("time")
+		close(done)
+		wg.Wait()
+
+		Expect(err).ToNot(HaveOccurred())
+		t, ok := ref.Type().(*myasthurts.Struct)
+		Expect(ok).To(BeTrue())
+		Expect(t.MethodsMap()).To(HaveKey("Unix"))
+	})
+
+	It("should let the listeners parse other packages", func() {
+		var (
+			stringsPkg, same *myasthurts.Package
+			stringsErr       error
+			sameErr          error
+			builder, users   myasthurts.Type
+			err              error
+		)
+		env, err = myasthurts.NewEnvironmentWithListener(afterListener(func(ctx *myasthurts.ParsePackageContext, filePath string, err error) error {
+			stringsPkg, stringsErr = env.Parse("strings")
+			if ref, ok := stringsPkg.RefTypeByName("Builder"); ok {
+				builder = ref.Type()
+			}
+			// The package being parsed is returned as it is.
+			same, sameErr = env.Parse(ctx.Package.ImportPath)
+			if ref, ok := ctx.Package.RefTypeByName("User"); ok {
+				users = ref.Type()
+			}
+			return err
+		}))
+		Expect(err).ToNot(HaveOccurred())
+		env.Config.Resolve = true
+
+		pkg, err := env.ParseDir("./data/tree/models")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stringsErr).ToNot(HaveOccurred())
+		Expect(stringsPkg.Explored).To(BeTrue())
+		Expect(builder).ToNot(BeNil())
+		Expect(sameErr).ToNot(HaveOccurred())
+		Expect(same).To(BeIdenticalTo(pkg))
+		// The types of the package are published when it is parsed.
+		Expect(users).To(BeNil())
+		user, ok := pkg.RefTypeByName("User")
+		Expect(ok).To(BeTrue())
+		Expect(user.Type()).ToNot(BeNil())
+	})
+
+	It("should build the same models regardless of the workers", func() {
+		sequential, err := myasthurts.NewEnvironment()
+		Expect(err).ToNot(HaveOccurred())
+		sequential.Config.Workers = 1
+
+		expected, err := sequential.ParsePattern("./data/tree/...")
+		Expect(err).ToNot(HaveOccurred())
+		packages, err := env.ParsePattern("./data/tree/...")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(packages).To(HaveLen(len(expected)))
+		for i, pkg := range packages {
+			Expect(pkg.ImportPath).To(Equal(expected[i].ImportPath))
+			Expect(pkg.Files).To(HaveLen(len(expected[i].Files)))
+			for j, file := range pkg.Files {
+				Expect(file.FileName).To(Equal(expected[i].Files[j].FileName))
+			}
+			Expect(pkg.Structs).To(HaveLen(len(expected[i].Structs)))
+			for j, s := range pkg.Structs {
+				Expect(s.Name()).To(Equal(expected[i].Structs[j].Name()))
+			}
+		}
+	})
+
+	It("should report the errors of the files parsed ahead", func() {
+		_, err := env.ParseDir("./data/broken")
+		Expect(err).To(MatchError(ContainSubstring("broken.go")))
+	})
+
+	It("should not report the errors of the files skipped", func() {
+		env, err := myasthurts.NewEnvironmentWithListener(beforeListener(func(ctx *myasthurts.ParsePackageContext, filePath string) error {
+			if strings.HasSuffix(filePath, "broken.go") {
+				return myasthurts.Skip
+			}
+			return nil
+		}))
+		Expect(err).ToNot(HaveOccurred())
+		env.Config.Workers = 4
+
+		pkg, err := env.ParseDir("./data/broken")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pkg.Structs).To(HaveLen(1))
+		Expect(pkg.Structs[0].Name()).To(Equal("User"))
+	})
+})
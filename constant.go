@@ -0,0 +1,391 @@
+package myasthurts
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Constant represents a constant declaration.
+type Constant struct {
+	Name string
+	// RefType is the type of the constant. For untyped constants, it is the
+	// default type of the value (Ex: int for `const A = 1`) and NullRefType
+	// while the value is not evaluated.
+	RefType RefType
+	Doc     Doc
+	// Expr is the source of the expression that defines the constant. Inside
+	// const blocks, implicitly repeated expressions are kept. Ex: `iota`.
+	Expr string
+	// Value is the evaluated value of the constant. Its kind is
+	// constant.Unknown when the expression could not be evaluated.
+	Value constant.Value
+	// Iota is the value of iota for the constant declaration.
+	Iota     int
+	Position Position
+	// Platforms where the constant exists, see Platform.
+	Platforms []Platform
+
+	ctx   *ParseFileContext
+	expr  ast.Expr
+	typed bool
+	// inferred is set when the type is taken from the expression, see eval.
+	inferred bool
+}
+
+// typedRefType returns the RefType of the constant if it is typed, nil
+// otherwise.
+func (c *Constant) typedRefType() RefType {
+	if c.typed {
+		return c.RefType
+	}
+	return nil
+}
+
+// eval tries to evaluate the constant value. It returns false when the value
+// could not be evaluated. That happens when the expression refers to
+// constants not evaluated yet or to unsupported expressions.
+func (c *Constant) eval() (ok bool) {
+	if c.expr == nil {
+		return false
+	}
+
+	// go/constant panics for invalid operations (Ex: adding a string to a
+	// number). Invalid expressions are just not evaluated.
+	defer func() {
+		if r := recover(); r != nil {
+			ok = false
+		}
+	}()
+
+	value, refType := evalConstExpr(c.ctx, c.expr, c.Iota)
+	if value.Kind() == constant.Unknown {
+		return false
+	}
+
+	switch {
+	case c.typed:
+		value = convertConst(value, c.RefType)
+	case refType != nil: // Implicitly typed. Ex: `const B = A`, where A is typed.
+		c.RefType = refType
+		c.typed, c.inferred = true, true
+	default:
+		c.RefType = defaultConstRefType(c.ctx, value, isRuneConstExpr(c.ctx, c.expr))
+	}
+	c.Value = value
+	return true
+}
+
+// resolveConstants evaluates the constants that could not be evaluated when
+// they were parsed. Constants can refer to others declared later in the
+// package, so it keeps trying while any new constant gets evaluated.
+func (p *Package) resolveConstants() {
+	for progress := true; progress; {
+		progress = false
+		for _, c := range p.Constants {
+			if c.Value.Kind() != constant.Unknown {
+				continue
+			}
+			if c.eval() {
+				progress = true
+			}
+		}
+	}
+}
+
+// resetConstants discards the values evaluated from the expressions of the
+// constants, so resolveConstants evaluates them again. It is used when the
+// declarations they refer to may have changed (see Environment.Reparse).
+// Values given by the type checker are kept.
+func (p *Package) resetConstants() {
+	for _, c := range p.Constants {
+		if c.expr == nil || (c.ctx != nil && c.ctx.info != nil) {
+			continue
+		}
+		c.Value = constant.MakeUnknown()
+		if c.inferred {
+			c.typed, c.inferred = false, false
+		}
+		if !c.typed {
+			c.RefType = NullRefType
+		}
+	}
+}
+
+// evalLen tries to evaluate the length of the array. It returns false when
+// the length could not be evaluated, and an error when the length expression
+// is invalid. Once evaluated, the array does not keep the parsing context.
+func (refType *ArrayRefType) evalLen() (ok bool, err error) {
+	if refType.lenExpr == nil {
+		return false, nil
+	}
+
+	// go/constant panics for invalid operations, see Constant.eval.
+	defer func() {
+		if r := recover(); r != nil {
+			ok, err = false, errors.Wrapf(ErrInvalidArrayLen, "%s: %v", refType.LenExpr, r)
+		}
+		if ok || err != nil {
+			refType.ctx, refType.lenExpr = nil, nil
+		}
+	}()
+
+	value, _ := evalConstExpr(refType.ctx, refType.lenExpr, 0)
+	if value.Kind() != constant.Int {
+		return false, nil
+	}
+	l, ok := constant.Int64Val(value)
+	if !ok {
+		return false, nil
+	}
+	if l < 0 { // -1 means the length is unknown.
+		return false, errors.Wrapf(ErrInvalidArrayLen, "%s: negative length %d", refType.LenExpr, l)
+	}
+	refType.Len = l
+	return true, nil
+}
+
+// resolveArrayLens evaluates the length of arrays that depend on constants
+// that could not be evaluated when the arrays were parsed. It must run after
+// resolveConstants.
+func (p *Package) resolveArrayLens() error {
+	pending := p.arrays[:0]
+	for _, array := range p.arrays {
+		ok, err := array.evalLen()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			pending = append(pending, array)
+		}
+	}
+	p.arrays = pending
+	return nil
+}
+
+// constantByName will return a constant defined on the context or in the dot
+// imported libraries.
+func (ctx *ParseFileContext) constantByName(name string) (*Constant, bool) {
+	if c, ok := ctx.Package.ConstantByName(name); ok {
+		return c, true
+	}
+	for _, pkg := range ctx.dotImports {
+		if c, ok := pkg.ConstantByName(name); ok {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// evalConstExpr evaluates a constant expression, returning its value and its
+// type. The RefType is nil for untyped values.
+func evalConstExpr(ctx *ParseFileContext, expr ast.Expr, iota int) (constant.Value, RefType) {
+	unknown := constant.MakeUnknown()
+
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		return constant.MakeFromLiteral(e.Value, e.Kind, 0), nil
+	case *ast.ParenExpr:
+		return evalConstExpr(ctx, e.X, iota)
+	case *ast.Ident:
+		if e.Name == "iota" {
+			return constant.MakeInt64(int64(iota)), nil
+		}
+		if c, ok := ctx.constantByName(e.Name); ok {
+			return c.Value, c.typedRefType()
+		}
+	// This case covers constants from other packages. Ex: time.Second
+	case *ast.SelectorExpr:
+		ident, ok := e.X.(*ast.Ident)
+		if !ok {
+			return unknown, nil
+		}
+		pkg, ok := ctx.PackageByImportAlias(ident.Name)
+		if !ok {
+			return unknown, nil
+		}
+		if c, ok := pkg.ConstantByName(e.Sel.Name); ok {
+			return c.Value, c.typedRefType()
+		}
+	case *ast.UnaryExpr:
+		x, refType := evalConstExpr(ctx, e.X, iota)
+		if x.Kind() == constant.Unknown {
+			return unknown, nil
+		}
+		return constant.UnaryOp(e.Op, x, 0), refType
+	case *ast.BinaryExpr:
+		x, xRefType := evalConstExpr(ctx, e.X, iota)
+		y, yRefType := evalConstExpr(ctx, e.Y, iota)
+		if x.Kind() == constant.Unknown || y.Kind() == constant.Unknown {
+			return unknown, nil
+		}
+		refType := xRefType
+		if refType == nil {
+			refType = yRefType
+		}
+
+		switch e.Op {
+		case token.SHL, token.SHR:
+			s, ok := constant.Uint64Val(constant.ToInt(y))
+			if !ok {
+				return unknown, nil
+			}
+			if xRefType == nil && x.Kind() == constant.Float {
+				// Untyped floats are shifted as integers. Ex: `2.0 << 1`.
+				x = constant.ToInt(x)
+			}
+			if x.Kind() != constant.Int {
+				return unknown, nil
+			}
+			// The result of a shift has the type of the left operand.
+			return constant.Shift(x, e.Op, uint(s)), xRefType
+		case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+			return constant.MakeBool(constant.Compare(x, e.Op, y)), nil
+		case token.QUO, token.REM:
+			if constant.Sign(y) == 0 { // Division by zero
+				return unknown, nil
+			}
+			if e.Op == token.QUO && x.Kind() == constant.Int && y.Kind() == constant.Int {
+				// Forces the integer division.
+				return constant.BinaryOp(x, token.QUO_ASSIGN, y), refType
+			}
+		}
+		return constant.BinaryOp(x, e.Op, y), refType
+	// This case covers conversions and the len of constant strings. Ex:
+	// Kind(1), len("abc")
+	case *ast.CallExpr:
+		if len(e.Args) != 1 {
+			return unknown, nil
+		}
+		x, _ := evalConstExpr(ctx, e.Args[0], iota)
+		if x.Kind() == constant.Unknown {
+			return unknown, nil
+		}
+		if ident, ok := e.Fun.(*ast.Ident); ok && ident.Name == "len" && x.Kind() == constant.String {
+			return constant.MakeInt64(int64(len(constant.StringVal(x)))), nil
+		}
+		refType, ok := constConversionRefType(ctx, e.Fun)
+		if !ok {
+			return unknown, nil
+		}
+		return convertConst(x, refType), refType
+	}
+	return unknown, nil
+}
+
+// constConversionRefType returns the RefType of a conversion expression
+// without registering new RefTypes. Ex: the `Kind` in `Kind(1)`.
+func constConversionRefType(ctx *ParseFileContext, expr ast.Expr) (RefType, bool) {
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return constConversionRefType(ctx, e.X)
+	case *ast.Ident:
+		return ctx.GetRefType(e.Name)
+	case *ast.SelectorExpr:
+		ident, ok := e.X.(*ast.Ident)
+		if !ok {
+			return nil, false
+		}
+		pkg, ok := ctx.PackageByImportAlias(ident.Name)
+		if !ok {
+			return nil, false
+		}
+		return pkg.RefTypeByName(e.Sel.Name)
+	}
+	return nil, false
+}
+
+// basicTypeName follows aliases and named types until a predeclared type is
+// found, returning its name. Ex: `int` for `type Kind int`. It returns an
+// empty string if the type is not based on a predeclared type.
+func basicTypeName(ref RefType) string {
+	// The limit protects against invalid cyclic declarations.
+	for i := 0; i < 100 && ref != nil; i++ {
+		ref = unaliasLocked(ref)
+		if named, ok := typeLocked(ref).(*NamedType); ok {
+			ref = named.Underlying
+			continue
+		}
+		if ref.Pkg() != nil && ref.Pkg().ImportPath == "builtin" {
+			return ref.Name()
+		}
+		return ""
+	}
+	return ""
+}
+
+// convertConst converts the value to the representation of the given type.
+// Ex: `float64(1)` is represented as a float.
+func convertConst(value constant.Value, ref RefType) constant.Value {
+	var converted constant.Value
+	switch name := basicTypeName(ref); {
+	case strings.HasPrefix(name, "int"), strings.HasPrefix(name, "uint"):
+		converted = constant.ToInt(value)
+	case strings.HasPrefix(name, "float"):
+		converted = constant.ToFloat(value)
+	case strings.HasPrefix(name, "complex"):
+		converted = constant.ToComplex(value)
+	default:
+		return value
+	}
+	if converted.Kind() == constant.Unknown {
+		return value
+	}
+	return converted
+}
+
+// isRuneConstExpr checks if the untyped constant expression is a rune. Ex:
+// `'a'` and `'a' + 1`. go/constant represents runes as integers.
+func isRuneConstExpr(ctx *ParseFileContext, expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		return e.Kind == token.CHAR
+	case *ast.ParenExpr:
+		return isRuneConstExpr(ctx, e.X)
+	case *ast.Ident:
+		c, ok := ctx.constantByName(e.Name)
+		return ok && !c.typed && c.RefType != nil && c.RefType.Name() == "rune"
+	case *ast.UnaryExpr:
+		return isRuneConstExpr(ctx, e.X)
+	case *ast.BinaryExpr:
+		switch e.Op {
+		case token.SHL, token.SHR:
+			return isRuneConstExpr(ctx, e.X)
+		case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+			return false
+		}
+		// Untyped integers become runes when mixed with runes.
+		return isRuneConstExpr(ctx, e.X) || isRuneConstExpr(ctx, e.Y)
+	}
+	return false
+}
+
+// defaultConstRefType returns the RefType of the default type for an untyped
+// constant value. Integer values are runes if isRune is set.
+func defaultConstRefType(ctx *ParseFileContext, value constant.Value, isRune bool) RefType {
+	var name string
+	switch value.Kind() {
+	case constant.Bool:
+		name = "bool"
+	case constant.String:
+		name = "string"
+	case constant.Int:
+		name = "int"
+		if isRune {
+			name = "rune"
+		}
+	case constant.Float:
+		name = "float64"
+	case constant.Complex:
+		name = "complex128"
+	default:
+		return NullRefType
+	}
+	if refType, ok := ctx.GetRefType(name); ok {
+		return refType
+	}
+	return NullRefType
+}
@@ -0,0 +1,125 @@
+package myasthurts_test
+
+import (
+	"go/constant"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	myasthurts "github.com/jamillosantos/go-my-ast-hurts"
+)
+
+var _ = Describe("Constant", func() {
+	Describe("Parse", func() {
+		var pkg *myasthurts.Package
+
+		BeforeEach(func() {
+			env, err := myasthurts.NewEnvironment()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(env.ParseFile(newDataPackageContext(env), "data/models19.sample.go")).To(Succeed())
+
+			var ok bool
+			pkg, ok = env.PackageByImportPath("data")
+			Expect(ok).To(BeTrue())
+		})
+
+		constantByName := func(name string) *myasthurts.Constant {
+			c, ok := pkg.ConstantByName(name)
+			ExpectWithOffset(1, ok).To(BeTrue())
+			return c
+		}
+
+		It("should not register constants as variables", func() {
+			Expect(pkg.Variables).To(BeEmpty())
+			Expect(pkg.Constants).To(HaveLen(22))
+		})
+
+		It("should evaluate iota with implicit repetition", func() {
+			kind, ok := pkg.RefTypeByName("Kind")
+			Expect(ok).To(BeTrue())
+
+			unknown := constantByName("KindUnknown")
+			Expect(unknown.Doc.Comments).To(HaveLen(1))
+			Expect(unknown.Expr).To(Equal("iota"))
+			Expect(unknown.RefType).To(Equal(kind))
+			Expect(unknown.Value.ExactString()).To(Equal("0"))
+
+			text := constantByName("KindText")
+			Expect(text.Expr).To(Equal("iota"))
+			Expect(text.Iota).To(Equal(1))
+			Expect(text.RefType).To(Equal(kind))
+			Expect(text.Value.ExactString()).To(Equal("1"))
+
+			video := constantByName("KindVideo")
+			Expect(video.Value.ExactString()).To(Equal("4"))
+
+			_, ok = pkg.ConstantByName("_")
+			Expect(ok).To(BeFalse())
+
+			Expect(constantByName("KB").Value.ExactString()).To(Equal("1024"))
+			Expect(constantByName("MB").Value.ExactString()).To(Equal("1048576"))
+			Expect(constantByName("GB").Expr).To(Equal("1 << (10 * (iota + 1))"))
+			Expect(constantByName("GB").Value.ExactString()).To(Equal("1073741824"))
+		})
+
+		It("should evaluate specs with many names", func() {
+			Expect(constantByName("A").Value.ExactString()).To(Equal("0"))
+			Expect(constantByName("B").Value.ExactString()).To(Equal("0"))
+			Expect(constantByName("C").Value.ExactString()).To(Equal("1"))
+			Expect(constantByName("D").Value.ExactString()).To(Equal("10"))
+		})
+
+		It("should evaluate constants referring to others", func() {
+			ratio := constantByName("Ratio")
+			Expect(ratio.Doc.Comments).To(HaveLen(1))
+			Expect(ratio.Value.ExactString()).To(Equal("2"))
+			Expect(ratio.RefType.Name()).To(Equal("int"))
+
+			big := constantByName("Big")
+			Expect(big.Value.ExactString()).To(Equal("5"))
+			Expect(big.RefType.Name()).To(Equal("Kind"))
+
+			Expect(constantByName("Converted").Value.ExactString()).To(Equal("7"))
+			Expect(constantByName("Converted").RefType.Name()).To(Equal("Kind"))
+		})
+
+		It("should define types of untyped constants", func() {
+			half := constantByName("Half")
+			Expect(half.RefType.Name()).To(Equal("float64"))
+			Expect(half.Value.Kind()).To(Equal(constant.Float))
+
+			name := constantByName("Name")
+			Expect(name.RefType.Name()).To(Equal("string"))
+			Expect(constant.StringVal(name.Value)).To(Equal("gopher"))
+
+			enabled := constantByName("Enabled")
+			Expect(enabled.RefType.Name()).To(Equal("bool"))
+			Expect(constant.BoolVal(enabled.Value)).To(BeTrue())
+		})
+
+		It("should define the type of untyped runes", func() {
+			letter := constantByName("Letter")
+			Expect(letter.RefType.Name()).To(Equal("rune"))
+			Expect(letter.Value.ExactString()).To(Equal("97"))
+
+			next := constantByName("NextLetter")
+			Expect(next.RefType.Name()).To(Equal("rune"))
+			Expect(next.Value.ExactString()).To(Equal("98"))
+
+			Expect(constantByName("Total").RefType.Name()).To(Equal("int"))
+		})
+
+		It("should shift untyped floats as integers", func() {
+			shifted := constantByName("Shifted")
+			Expect(shifted.Value.ExactString()).To(Equal("16"))
+			Expect(shifted.RefType.Name()).To(Equal("int"))
+		})
+
+		It("should keep unknown values for constants that cannot be evaluated", func() {
+			timeout := constantByName("Timeout")
+			Expect(timeout.Expr).To(Equal("time.Second"))
+			Expect(timeout.Value.Kind()).To(Equal(constant.Unknown))
+		})
+	})
+})
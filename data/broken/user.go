@@ -0,0 +1,5 @@
+package broken
+
+type User struct {
+	Name string
+}
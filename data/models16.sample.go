@@ -0,0 +1,31 @@
+package models
+
+type Number interface {
+	~int | ~int64 | float64
+}
+
+type Set[T comparable] struct {
+	items map[T]struct{}
+}
+
+func (s *Set[T]) Add(value T) {
+	s.items[value] = struct{}{}
+}
+
+type Pair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+type Catalog struct {
+	Names Set[string]
+	Entry Pair[string, int64]
+}
+
+func Sum[N Number](values ...N) N {
+	var total N
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
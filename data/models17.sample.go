@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+func (s Status) Valid() bool {
+	return s >= StatusActive
+}
+
+// Status of an user.
+type Status int
+
+func (s Status) String() string {
+	return "status"
+}
+
+type Handler func(name string) error
+
+type IDs []string
+
+type Timestamp time.Time
+
+type Admin User
+
+type User struct {
+	ID     int64
+	Status Status
+}
+
+type Profile struct {
+	Named Named
+}
+
+type Named interface {
+	Name() string
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+type User struct {
+	Name string
+}
+
+func (u *User) SetName(value Name) {
+	u.Name = value
+}
+
+func (p *Person) GetName() Name {
+	return p.Name
+}
+
+// Name is kept for compatibility.
+type Name = string
+
+type Person = User
+
+type Time = time.Time
+
+type HasName interface {
+	GetName() string
+	SetName(value string)
+}
@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// Kind of a document.
+type Kind int
+
+const (
+	// KindUnknown is the zero value.
+	KindUnknown Kind = iota
+	KindText
+	KindImage
+	_
+	KindVideo
+)
+
+const (
+	KB = 1 << (10 * (iota + 1))
+	MB
+	GB
+)
+
+const (
+	A, B = iota, iota * 10
+	C, D
+)
+
+// Ratio is declared before Total.
+const Ratio = Total / 4
+
+const Total = 10
+
+const Half float64 = 1
+
+const Big = KindVideo + 1
+
+const Name = "go" + "pher"
+
+const Enabled = Total > 5
+
+const Converted = Kind(7)
+
+const Timeout = time.Second
+
+const Letter = 'a'
+
+const NextLetter = Letter + 1
+
+const Shifted = 2.0 << 3
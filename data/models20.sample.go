@@ -0,0 +1,25 @@
+package models
+
+var (
+	// Coordinates of the origin.
+	x, y int
+	name string
+)
+
+type Point struct {
+	// X and Y share this comment.
+	X, Y  float64 `json:"coord"`
+	Label string
+}
+
+func (Point) Kind() string {
+	return "point"
+}
+
+func move(p *Point, dx, dy float64) (moved *Point, err error) {
+	return p, nil
+}
+
+type Mover interface {
+	Move(dx, dy float64) (x, y float64)
+}
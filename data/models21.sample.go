@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// Color of a product.
+type Color int
+
+// Size of a product.
+type Size string
+
+type Names []string
+
+const (
+	// ColorRed is the red color.
+	ColorRed Color = iota + 1
+	ColorGreen
+	ColorBlue
+)
+
+const (
+	SizeSmall  Size = "S"
+	SizeMedium Size = "M"
+)
+
+const DefaultColor = ColorGreen
+
+const FallbackColor Color = (DefaultColor)
+
+const Large = "L"
+
+const SizeLarge Size = Large
+
+const MaxItems = 10
+
+const Delay time.Duration = 5
@@ -0,0 +1,32 @@
+package models
+
+import "sync"
+
+type Base struct {
+	ID        int64
+	Title     string
+	CreatedAt string
+}
+
+func (b *Base) Identifier() int64 {
+	return b.ID
+}
+
+type Audit struct {
+	CreatedAt string
+	UpdatedAt string
+}
+
+func (a Audit) Touch() {}
+
+type Named interface {
+	Name() string
+}
+
+type Entity struct {
+	Base
+	*Audit
+	sync.Mutex
+	Named
+	Title string
+}
@@ -0,0 +1,41 @@
+package models
+
+import "io"
+
+type Named interface {
+	Name() string
+}
+
+type Closer interface {
+	io.Closer
+	Named
+}
+
+type Resource interface {
+	Closer
+	ID() int64
+}
+
+type File struct{}
+
+func (f *File) Close() error {
+	return nil
+}
+
+func (f *File) Name() string {
+	return "file"
+}
+
+func (f *File) ID() int64 {
+	return 1
+}
+
+type Partial struct{}
+
+func (p *Partial) Name() string {
+	return "partial"
+}
+
+func (p *Partial) ID() int64 {
+	return 2
+}
@@ -0,0 +1,33 @@
+package models
+
+type Stringer interface {
+	String() string
+}
+
+type Setter interface {
+	Set(value string)
+}
+
+type Value struct {
+	value string
+}
+
+func (v Value) String() string {
+	return v.value
+}
+
+func (v *Value) Set(value string) {
+	v.value = value
+}
+
+type ByValue struct {
+	Value
+}
+
+type ByPointer struct {
+	*Value
+}
+
+type ValuePtr = *Value
+
+type ValueAlias = Value
@@ -0,0 +1,52 @@
+package models
+
+import "io"
+
+type User struct {
+	Name string
+}
+
+type UserID int64
+
+type Bytes = []byte
+
+type Repository interface {
+	Save(user *User, users []*User) error
+	Find(filter map[string][]int, done chan bool) (*User, error)
+	Each(fn func(*User, int) bool)
+	Merge(opts ...struct{ Name string })
+}
+
+type Reader struct{}
+
+func (r *Reader) Read(p Bytes) (int, error) {
+	return 0, io.EOF
+}
+
+type UserRepository struct{}
+
+func (r *UserRepository) Save(user *User, users []*User) error {
+	return nil
+}
+
+func (r *UserRepository) Find(filter map[string][]int, done chan bool) (*User, error) {
+	return nil, nil
+}
+
+func (r *UserRepository) Each(fn func(u *User, i int) bool) {}
+
+func (r *UserRepository) Merge(opts ...struct{ Name string }) {}
+
+type IDRepository struct{}
+
+func (r *IDRepository) Save(user *UserID, users []*User) error {
+	return nil
+}
+
+func (r *IDRepository) Find(filter map[string][]int64, done chan bool) (*User, error) {
+	return nil, nil
+}
+
+func (r *IDRepository) Each(fn func(*User, int) bool) {}
+
+func (r *IDRepository) Merge(opts ...struct{ Name string }) {}
@@ -0,0 +1,17 @@
+package models
+
+import "crypto/sha256"
+
+type Header struct {
+	Magic    [4]byte
+	Payload  [MaxItems]byte
+	Checksum [sha256.Size]byte
+	Matrix   [Rows * 2][Rows]int
+	Items    []string
+	Buffer   [MaxItems]byte
+}
+
+const (
+	Rows     = 3
+	MaxItems = 1 << Rows
+)
@@ -0,0 +1,28 @@
+package models
+
+type Job struct{}
+
+type Worker interface {
+	Run(jobs <-chan Job, results chan<- error)
+	Done() <-chan struct{}
+}
+
+type Pool struct {
+	Jobs    chan Job
+	Results chan<- error
+	Ready   <-chan bool
+}
+
+func (p *Pool) Run(jobs <-chan Job, results chan<- error) {}
+
+func (p *Pool) Done() <-chan struct{} {
+	return nil
+}
+
+type BrokenPool struct{}
+
+func (p *BrokenPool) Run(jobs chan Job, results chan<- error) {}
+
+func (p *BrokenPool) Done() <-chan struct{} {
+	return nil
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+type List[T any] struct {
+	Items []T
+}
+
+type Event struct {
+	Times    *[]time.Time
+	Handlers map[string]func(context.Context, ...string) (bool, error)
+	Buffer   [4]byte
+	Updates  chan (<-chan *Event)
+	Labels   List[time.Duration]
+	Meta     struct {
+		Name string `json:"name"`
+		time.Location
+	}
+	Visitor interface {
+		Visit(e *Event) error
+	}
+	Any    interface{}
+	Single func() func() int
+}
@@ -0,0 +1,36 @@
+package models
+
+// Limit of items.
+const Limit = 10
+
+var Default = "user"
+
+type User struct {
+	Name string `json:"name"`
+	Age  int
+}
+
+type Finder interface {
+	Find(name string) (*User, error)
+}
+
+func (u *User) Rename(name string) error {
+	u.Name = name
+	return nil
+}
+
+const MinAge, MaxAge = 18, 99
+
+var first, last string
+
+type Point struct {
+	X, Y float64
+}
+
+func Move(p *Point, dx, dy float64) (x, y float64) {
+	return p.X + dx, p.Y + dy
+}
+
+type Mover interface {
+	Move(dx, dy float64)
+}
@@ -0,0 +1,33 @@
+//go:build linux && amd64
+
+// Package models has the models of the application.
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+const Version = "1.0"
+
+var Started time.Time
+
+type ID = int64
+
+type Status int
+
+type User struct {
+	ID ID
+}
+
+type Namer interface {
+	Name() string
+}
+
+func (u *User) String() string {
+	return fmt.Sprint(u.ID)
+}
+
+func NewUser() *User {
+	return &User{}
+}
@@ -0,0 +1,7 @@
+//go:build (linux || darwin) && amd64
+// +build linux darwin
+// +build amd64
+
+package models
+
+type Legacy struct{}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"fmt"
+	// PNG decoder registration.
+	_ "image/png"
+	. "strings"
+	t "time"
+)
+
+type Event struct {
+	At   t.Time
+	Name Builder
+}
+
+func (e Event) String() string {
+	return fmt.Sprint(e.At)
+}
@@ -0,0 +1,11 @@
+package models
+
+import (
+	"bufio"
+	"time"
+)
+
+type Entry struct {
+	CreatedAt time.Time
+	Reader    *bufio.Reader
+}
@@ -0,0 +1,7 @@
+package models
+
+type Invalid struct {
+	Values [Rows % 1.5]int
+}
+
+const Rows = 3
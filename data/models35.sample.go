@@ -0,0 +1,29 @@
+package models
+
+func (l *List[T]) Push(value T) {
+	l.items = append(l.items, value)
+}
+
+type List[T Ordered] struct {
+	items []T
+}
+
+type Integer interface {
+	int
+}
+
+type Ordered interface {
+	Integer | ~string
+	Temperature
+	Stringer
+}
+
+type Stringer interface {
+	String() string
+}
+
+type Temperature interface {
+	Celsius
+}
+
+type Celsius float64
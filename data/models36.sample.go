@@ -0,0 +1,14 @@
+package models
+
+type Kind int
+
+const (
+	K0 Kind = iota
+	K1
+	K2
+	Default = K1
+)
+
+const Other = K1
+
+const Last Kind = K2 + 0
@@ -0,0 +1,10 @@
+package models
+
+type Negative struct {
+	Values [Low - High]byte
+}
+
+const (
+	Low  = 2
+	High = 3
+)
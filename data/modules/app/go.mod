@@ -0,0 +1,15 @@
+module example.com/app
+
+go 1.21
+
+require (
+	example.com/Upper v1.0.0
+	example.com/app/plugins v1.0.0
+	example.com/lib v1.2.0 // indirect
+	github.com/pkg/errors v0.9.1
+)
+
+replace example.com/lib => ../lib
+
+// Only v0.1.0 is replaced.
+replace example.com/old v0.1.0 => example.com/new v0.2.0
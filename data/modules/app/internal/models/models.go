@@ -0,0 +1,11 @@
+package models
+
+import (
+	"example.com/lib/types"
+	"github.com/pkg/errors"
+)
+
+type User struct {
+	ID    types.ID
+	Cause errors.Frame
+}
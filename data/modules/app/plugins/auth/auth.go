@@ -0,0 +1,4 @@
+package auth
+
+// Local is not part of example.com/app, plugins is a module of its own.
+type Local struct{}
@@ -0,0 +1,3 @@
+package text
+
+type Text string
@@ -0,0 +1,3 @@
+package auth
+
+type Token string
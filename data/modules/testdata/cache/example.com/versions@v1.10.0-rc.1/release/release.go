@@ -0,0 +1,3 @@
+package release
+
+const Version = "v1.10.0-rc.1"
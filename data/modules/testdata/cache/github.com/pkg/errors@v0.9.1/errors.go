@@ -0,0 +1,5 @@
+package errors
+
+func New(message string) error {
+	return nil
+}
@@ -0,0 +1,7 @@
+package main
+
+import "example.com/dep"
+
+type App struct {
+	Dep dep.Dep
+}
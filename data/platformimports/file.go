@@ -0,0 +1,6 @@
+// Package platformimports is a fixture for imports of platform specific files.
+package platformimports
+
+type File struct {
+	Name string
+}
@@ -0,0 +1,7 @@
+package platformimports
+
+import "github.com/jamillosantos/go-my-ast-hurts/data/platformimports/winapi"
+
+type fileHandle struct {
+	Handle winapi.Handle
+}
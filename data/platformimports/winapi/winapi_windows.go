@@ -0,0 +1,4 @@
+// Package winapi is a fixture of a package only built on Windows.
+package winapi
+
+type Handle uintptr
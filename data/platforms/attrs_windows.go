@@ -0,0 +1,6 @@
+package platforms
+
+// The method is declared before the type of its platform.
+func (s stat) attrs() uint32 {
+	return s.Attrs
+}
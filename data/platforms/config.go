@@ -0,0 +1,9 @@
+package platforms
+
+type Config struct {
+	Path string
+}
+
+func Load() Config {
+	return Config{}
+}
@@ -0,0 +1,5 @@
+//go:build debug
+
+package platforms
+
+var Debug = true
@@ -0,0 +1,11 @@
+package platforms
+
+const PathSeparator = '/'
+
+type stat struct {
+	Ino uint64
+}
+
+func (s *stat) id() uint64 {
+	return s.Ino
+}
@@ -0,0 +1,12 @@
+package platforms
+
+const PathSeparator = '\\'
+
+type stat struct {
+	Index uint32
+	Attrs uint32
+}
+
+func (s *stat) id() uint64 {
+	return uint64(s.Index)
+}
@@ -0,0 +1,5 @@
+package platforms
+
+type watcher struct {
+	last stat
+}
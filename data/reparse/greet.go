@@ -0,0 +1,12 @@
+package reparse
+
+var DefaultUser User
+
+type Admin struct {
+	User
+	Level int
+}
+
+func (u User) Greet() string {
+	return "Hello, " + u.Name
+}
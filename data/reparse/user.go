@@ -0,0 +1,16 @@
+package reparse
+
+const MaxNameLen = 32
+
+// User is a user of the application.
+type User struct {
+	Name string
+}
+
+func (u *User) Rename(name string) {
+	u.Name = name
+}
+
+func NewUser(name string) *User {
+	return &User{Name: name}
+}
@@ -0,0 +1,11 @@
+// Package calc is a fixture for parsing test files.
+package calc
+
+// Calculator sums numbers.
+type Calculator struct {
+	Total int
+}
+
+func Add(a, b int) int {
+	return a + b
+}
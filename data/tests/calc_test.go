@@ -0,0 +1,20 @@
+package calc
+
+import "testing"
+
+type fixture struct {
+	A, B int
+	Want int
+}
+
+var fixtures = []fixture{
+	{1, 2, 3},
+}
+
+func TestAdd(t *testing.T) {
+	for _, f := range fixtures {
+		if got := Add(f.A, f.B); got != f.Want {
+			t.Errorf("Add(%d, %d) = %d", f.A, f.B, got)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package calc_test
+
+import (
+	"fmt"
+
+	"github.com/jamillosantos/go-my-ast-hurts/data/tests"
+)
+
+// Example wraps a calculator for the examples.
+type Example struct {
+	Calculator calc.Calculator
+}
+
+func ExampleAdd() {
+	fmt.Println(calc.Sum(1, 2))
+	// Output: 3
+}
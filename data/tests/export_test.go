@@ -0,0 +1,4 @@
+package calc
+
+// Sum exports Add for the external tests.
+var Sum = Add
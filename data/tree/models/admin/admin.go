@@ -0,0 +1,7 @@
+package admin
+
+import "github.com/jamillosantos/go-my-ast-hurts/data/tree/models"
+
+type Admin struct {
+	models.User
+}
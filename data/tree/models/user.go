@@ -0,0 +1,7 @@
+package models
+
+import "github.com/jamillosantos/go-my-ast-hurts/data/tree"
+
+type User struct {
+	Root tree.Root
+}
@@ -0,0 +1,3 @@
+package broken
+
+This file is not valid Go.
@@ -0,0 +1,6 @@
+// Package tree is the root of a package tree.
+package tree
+
+type Root struct {
+	Name string
+}
@@ -0,0 +1,5 @@
+package invalid
+
+type Broken struct {
+	Field Missing
+}
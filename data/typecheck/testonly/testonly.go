@@ -0,0 +1,6 @@
+package testonly
+
+import "github.com/jamillosantos/go-my-ast-hurts/data/tests"
+
+// Total uses calc.Sum, only declared by the test files of calc.
+var Total = calc.Sum(1, 2)
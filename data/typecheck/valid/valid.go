@@ -0,0 +1,19 @@
+package valid
+
+import (
+	. "time"
+	"unsafe"
+)
+
+const (
+	Timeout  = Second * 2
+	WordSize = unsafe.Sizeof(uintptr(0))
+	Name     = "valid"
+)
+
+type Clock struct {
+	Now    Time
+	Buffer [WordSize * 2]byte
+}
+
+const Letter = 'a'
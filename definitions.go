@@ -2,23 +2,24 @@ package myasthurts
 
 import (
 	"fmt"
+	"go/ast"
 	"go/build"
+	"go/token"
 	"regexp"
 	"strings"
+	"sync"
 )
 
-type Constant struct {
-	Name string
-	Type Type
-}
-
 type Doc struct {
 	Comments []string
 }
 
+// Position is the location of an element in the source code.
 type Position struct {
-	FileName string
-	Line     int
+	// Start is the position of the first character of the element.
+	Start token.Position
+	// End is the position immediately after the element.
+	End token.Position
 }
 
 // EnvConfig is a Struct to set config in Environment
@@ -26,6 +27,39 @@ type EnvConfig struct {
 	DevMode    bool
 	ASTI       bool
 	CurrentDir string
+	// ModCache is the directory of the module cache. If it is empty,
+	// GOMODCACHE or the `pkg/mod` directory of the first GOPATH is used.
+	ModCache string
+	// Resolve enables the on-demand resolution of imported packages. When it
+	// is set, the first access to the Type of a RefType from a package not
+	// explored parses the package. Listeners must not read the Type of
+	// RefTypes not resolved yet, because they run while the environment is
+	// parsing and the resolution waits for it.
+	Resolve bool
+	// MaxResolveDepth limits the on-demand resolution to packages imported at
+	// most MaxResolveDepth imports away from the packages parsed directly.
+	// Ex: 1 resolves only the packages imported by the parsed ones. 0 means
+	// no limit.
+	MaxResolveDepth int
+	// TypeCheck enables type checking the packages with go/types before
+	// parsing them. The model is the same, but types and constants are
+	// resolved by the type checker, which is more precise and slower. Files
+	// parsed directly by Environment.ParseFile are not type checked.
+	TypeCheck bool
+	// Platforms enables parsing the packages for many platforms. The files
+	// of all platforms are parsed and each declaration records the
+	// platforms where it exists. If it is empty, only the files for the
+	// BuildContext are parsed.
+	Platforms []Platform
+	// IncludeTests enables parsing the test files of the packages parsed
+	// directly. The `_test.go` files of the package are parsed as part of
+	// it and the external test package (`package x_test`) is parsed as its
+	// own Package (see Package.XTest).
+	IncludeTests bool
+	// Workers is the number of goroutines parsing files in parallel. The
+	// models are still built by one goroutine at a time, in the same order.
+	// Zero means runtime.GOMAXPROCS(0).
+	Workers int
 }
 
 func (ec EnvConfig) CWD() string {
@@ -34,44 +68,67 @@ func (ec EnvConfig) CWD() string {
 
 // Field is utilized in Struct type in the present moment.
 type Field struct {
-	Name     string
-	RefType  RefType
+	Name    string
+	RefType RefType
+	// Embedded is set for embedded fields. Ex: `time.Time` in
+	// `struct { time.Time }`. Embedded fields are named after their type.
+	Embedded bool
 	Tag      Tag
 	Doc      Doc
 	Position Position
 }
 
-// File is utilized to represent each file read in Package.
-type File struct {
-	Package    *Package
-	FileName   string
+type Package struct {
+	Name       string
+	ImportPath string
+	RealPath   string
+	BuildInfo  *build.Package
 	Doc        Doc
+	Explored   bool
 	Variables  []*Variable
 	Constants  []*Constant
+	Methods    []*MethodDescriptor
+	MethodsMap map[string]*MethodDescriptor
 	Structs    []*Struct
 	Interfaces []*Interface
-	Files      []*File
-}
-
-type Package struct {
-	Name        string
-	ImportPath  string
-	RealPath    string
-	BuildInfo   *build.Package
-	Doc         Doc
-	Explored    bool
-	Variables   []*Variable
-	Constants   []*Constant
-	Methods     []*MethodDescriptor
-	MethodsMap  map[string]*MethodDescriptor
-	Structs     []*Struct
-	Interfaces  []*Interface
-	RefType     []RefType
-	refTypeMap  map[string]RefType
+	NamedTypes []*NamedType
+	Aliases    []*Alias
+	arrays     []*ArrayRefType
+	// interfaces keeps the interfaces embedding types not resolved yet, see
+	// Interface.Embeds.
+	interfaces []*Interface
+	RefType    []RefType
+	refTypeMap map[string]RefType
+	// platformRefTypes keeps the RefTypes of the declarations for other
+	// platforms than the ones in refTypeMap, by name.
+	platformRefTypes map[string][]*platformRefType
+	// mu guards the declarations of the package (Ex: Structs, RefType),
+	// read by other goroutines while the package is parsed, and loading. The
+	// exported fields must only be read after the package is parsed, while
+	// the methods (Ex: StructByName) are safe for concurrent use.
+	mu          sync.RWMutex
 	Types       []Type
 	Files       []*File
 	Parent      *Package
 	Subpackages []*Package
+	// XTest is the external test package of the package. Ex: `time_test`
+	// for `time`. It is only set when EnvConfig.IncludeTests is enabled.
+	XTest *Package
+	// ForTest is the package tested by the external test package.
+	ForTest *Package
+
+	// env is the environment the package belongs to. It is used for
+	// resolving the package on demand.
+	env *Environment
+	// depth is the number of imports between the package and the packages
+	// parsed directly.
+	depth int
+	// loading counts the files of the package being parsed. The types of the
+	// package are not published while it is loading, see BaseRefType.Type.
+	loading int
+	// resolveErr keeps the error of the on demand resolution, so it is not
+	// retried.
+	resolveErr error
 }
 
 func NewPackage(buildPackage *build.Package) *Package {
@@ -86,6 +143,8 @@ func NewPackage(buildPackage *build.Package) *Package {
 		MethodsMap: make(map[string]*MethodDescriptor),
 		Structs:    make([]*Struct, 0),
 		Interfaces: make([]*Interface, 0),
+		NamedTypes: make([]*NamedType, 0),
+		Aliases:    make([]*Alias, 0),
 		RefType: []RefType{
 			NullRefType,
 			InterfaceRefType,
@@ -107,7 +166,10 @@ type RefType interface {
 type BaseRefType struct {
 	name string
 	pkg  *Package
-	t    Type
+
+	// mu guards t, which the parser sets while other goroutines read it.
+	mu sync.RWMutex
+	t  Type
 }
 
 var (
@@ -135,13 +197,49 @@ func (refType *BaseRefType) Pkg() *Package {
 	return refType.pkg
 }
 
+// Type returns the Type the RefType refers to. It is nil for types not
+// declared yet or from packages not explored. If EnvConfig.Resolve is set,
+// the package of the type is parsed on the first access.
+//
+// Types are published when their package is built: it is nil while the
+// package is being parsed.
 func (refType *BaseRefType) Type() Type {
+	if refType.pkg == nil {
+		return refType.loadType()
+	}
+	if !refType.pkg.isLoading() {
+		if t := refType.loadType(); t != nil {
+			return t
+		}
+	}
+	_ = refType.pkg.resolve()
+	if refType.pkg.isLoading() {
+		return nil
+	}
+	return refType.loadType()
+}
+
+// typeLocked is Type for the parser, which already holds the parse lock of
+// the environment.
+func (refType *BaseRefType) typeLocked() Type {
+	if t := refType.loadType(); t != nil || refType.pkg == nil {
+		return t
+	}
+	_ = refType.pkg.resolveLocked(false)
+	return refType.loadType()
+}
+
+func (refType *BaseRefType) loadType() Type {
+	refType.mu.RLock()
+	defer refType.mu.RUnlock()
 	return refType.t
 }
 
 // AppendType add Type in RefType
 func (rt *BaseRefType) AppendType(tp Type) {
+	rt.mu.Lock()
 	rt.t = tp
+	rt.mu.Unlock()
 }
 
 type StarRefType struct {
@@ -170,13 +268,53 @@ func (refType *StarRefType) AppendType(tp Type) {
 	refType.RefType.AppendType(tp)
 }
 
+// SliceRefType represents a slice of a RefType. Ex: `[]T`.
+type SliceRefType struct {
+	RefType
+}
+
+func NewSliceRefType(refType RefType) *SliceRefType {
+	return &SliceRefType{
+		RefType: refType,
+	}
+}
+
+func (refType *SliceRefType) Name() string {
+	return refType.RefType.Name()
+}
+
+func (refType *SliceRefType) Pkg() *Package {
+	return refType.RefType.Pkg()
+}
+
+func (refType *SliceRefType) Type() Type {
+	return refType.RefType.Type()
+}
+
+func (refType *SliceRefType) AppendType(tp Type) {
+	refType.RefType.AppendType(tp)
+}
+
+// ArrayRefType represents an array of a RefType. Ex: `[4]T`.
 type ArrayRefType struct {
 	RefType
+	// LenExpr is the source of the length expression. Ex: `MaxItems` in
+	// `[MaxItems]byte`.
+	LenExpr string
+	// Len is the evaluated length of the array. It is -1 while the length
+	// could not be evaluated.
+	Len int64
+
+	ctx     *ParseFileContext
+	lenExpr ast.Expr
 }
 
-func NewArrayRefType(refType RefType) *ArrayRefType {
+// NewArrayRefType creates a new ArrayRefType with the given length. Use -1
+// for unknown lengths.
+func NewArrayRefType(refType RefType, len int64) *ArrayRefType {
 	return &ArrayRefType{
 		RefType: refType,
+		Len:     len,
 	}
 }
 
@@ -196,13 +334,28 @@ func (refType *ArrayRefType) AppendType(tp Type) {
 	refType.RefType.AppendType(tp)
 }
 
+// ChanDir is the direction of a channel.
+type ChanDir int
+
+const (
+	// ChanBoth is a bidirectional channel. Ex: `chan T`.
+	ChanBoth ChanDir = iota
+	// ChanSend is a send-only channel. Ex: `chan<- T`.
+	ChanSend
+	// ChanRecv is a receive-only channel. Ex: `<-chan T`.
+	ChanRecv
+)
+
+// ChanRefType represents a channel of a RefType.
 type ChanRefType struct {
 	RefType
+	Dir ChanDir
 }
 
-func NewChanRefType(refType RefType) *ChanRefType {
+func NewChanRefType(refType RefType, dir ChanDir) *ChanRefType {
 	return &ChanRefType{
 		RefType: refType,
+		Dir:     dir,
 	}
 }
 
@@ -248,6 +401,36 @@ func (refType *EllipsisRefType) AppendType(tp Type) {
 	refType.RefType.AppendType(tp)
 }
 
+// InstanceRefType represents an instantiation of a generic type. Ex:
+// `Set[string]`. The embedded RefType is the generic type being instantiated.
+type InstanceRefType struct {
+	RefType
+	TypeArgs []RefType
+}
+
+func NewInstanceRefType(refType RefType, typeArgs []RefType) *InstanceRefType {
+	return &InstanceRefType{
+		RefType:  refType,
+		TypeArgs: typeArgs,
+	}
+}
+
+func (refType *InstanceRefType) Name() string {
+	return refType.RefType.Name()
+}
+
+func (refType *InstanceRefType) Pkg() *Package {
+	return refType.RefType.Pkg()
+}
+
+func (refType *InstanceRefType) Type() Type {
+	return refType.RefType.Type()
+}
+
+func (refType *InstanceRefType) AppendType(tp Type) {
+	refType.RefType.AppendType(tp)
+}
+
 type MapType struct {
 	pkg   *Package
 	Key   RefType
@@ -309,9 +492,14 @@ type Type interface {
 }
 
 type BaseType struct {
-	pkg        *Package
-	name       string
-	methods    []*TypeMethod
+	pkg  *Package
+	name string
+	// mu guards the methods, added by the files of the package while other
+	// goroutines read them. It is a pointer as BaseType is embedded by value.
+	mu      *sync.RWMutex
+	methods []*TypeMethod
+	// methodsMap is replaced, instead of changed, when methods are added or
+	// removed. So, the maps returned by MethodsMap do not change.
 	methodsMap map[string]*TypeMethod
 }
 
@@ -320,6 +508,7 @@ func NewBaseType(pkg *Package, name string) *BaseType {
 	return &BaseType{
 		pkg:        pkg,
 		name:       name,
+		mu:         &sync.RWMutex{},
 		methods:    make([]*TypeMethod, 0),
 		methodsMap: make(map[string]*TypeMethod, 0),
 	}
@@ -334,19 +523,61 @@ func (t *BaseType) Name() string {
 }
 
 func (t *BaseType) Methods() []*TypeMethod {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
 	return t.methods
 }
 
 func (t *BaseType) MethodsMap() map[string]*TypeMethod {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
 	return t.methodsMap
 }
 
 func (t *BaseType) AddMethod(method *TypeMethod) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	t.methods = append(t.methods, method)
-	t.methodsMap[method.Descriptor.Name()] = method
+	methodsMap := t.copyMethodsMap()
+	methodsMap[method.Descriptor.Name()] = method
+	t.methodsMap = methodsMap
+}
+
+// RemoveMethod removes the method with the given descriptor. It returns false
+// if the method was not added to the type.
+func (t *BaseType) RemoveMethod(descriptor *MethodDescriptor) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, m := range t.methods {
+		if m.Descriptor != descriptor {
+			continue
+		}
+		t.methods = append(t.methods[:i:i], t.methods[i+1:]...)
+		methodsMap := t.copyMethodsMap()
+		delete(methodsMap, descriptor.Name())
+		// Methods declared for many platforms share the name.
+		for _, other := range t.methods {
+			if other.Descriptor.Name() == descriptor.Name() {
+				methodsMap[descriptor.Name()] = other
+			}
+		}
+		t.methodsMap = methodsMap
+		return true
+	}
+	return false
+}
+
+func (t *BaseType) copyMethodsMap() map[string]*TypeMethod {
+	methodsMap := make(map[string]*TypeMethod, len(t.methodsMap)+1)
+	for name, m := range t.methodsMap {
+		methodsMap[name] = m
+	}
+	return methodsMap
 }
 
 type TypeMethod struct {
+	// Name is the name of the method, not of its receiver. Ex: `getName` for
+	// `func (u *User) getName()`.
 	Name       string
 	Descriptor *MethodDescriptor
 }
@@ -363,9 +594,12 @@ type TagParam struct {
 }
 
 type Variable struct {
-	Name    string
-	RefType RefType
-	Doc     Doc
+	Name     string
+	RefType  RefType
+	Doc      Doc
+	Position Position
+	// Platforms where the variable exists, see Platform.
+	Platforms []Platform
 }
 
 // FormatComment is simple method to remove // or /* */ of comment
@@ -382,14 +616,25 @@ func (doc *Doc) FormatComment() string {
 	return str
 }
 
+// AppendFile appends a file parsed for the package.
+func (p *Package) AppendFile(f *File) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.Files = append(p.Files, f)
+}
+
 // AppendStruct add new Struct in Package
 func (p *Package) AppendStruct(s *Struct) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	p.Structs = append(p.Structs, s)
 	p.Types = append(p.Types, s)
 }
 
 // StructByName find Struct by name.
 func (p *Package) StructByName(name string) (*Struct, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
 	for _, e := range p.Structs {
 		if e.Name() == name {
 			return e, true
@@ -400,12 +645,16 @@ func (p *Package) StructByName(name string) (*Struct, bool) {
 
 // AppendInterface registers a new Interface to the package.
 func (p *Package) AppendInterface(s *Interface) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	p.Interfaces = append(p.Interfaces, s)
 	p.Types = append(p.Types, s)
 }
 
 // StructByName find Struct by name.
 func (p *Package) InterfaceByName(name string) (*Interface, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
 	for _, e := range p.Interfaces {
 		if e.Name() == name {
 			return e, true
@@ -414,27 +663,77 @@ func (p *Package) InterfaceByName(name string) (*Interface, bool) {
 	return nil, false
 }
 
+// AppendNamedType registers a new NamedType to the package.
+func (p *Package) AppendNamedType(t *NamedType) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.NamedTypes = append(p.NamedTypes, t)
+	p.Types = append(p.Types, t)
+}
+
+// NamedTypeByName find NamedType by name.
+func (p *Package) NamedTypeByName(name string) (*NamedType, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	for _, e := range p.NamedTypes {
+		if e.Name() == name {
+			return e, true
+		}
+	}
+	return nil, false
+}
+
+// AppendAlias registers a new Alias to the package.
+func (p *Package) AppendAlias(a *Alias) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.Aliases = append(p.Aliases, a)
+	p.Types = append(p.Types, a)
+}
+
+// AliasByName find Alias by name.
+func (p *Package) AliasByName(name string) (*Alias, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	for _, e := range p.Aliases {
+		if e.Name() == name {
+			return e, true
+		}
+	}
+	return nil, false
+}
+
 // EnsureRefType will try to get the RefType from the list by the name param. If
 // the RefType exists, it will return the reference with true (second return).
 // If it is does not exists in the list, the function will create a new type and
 // return it with false (second return).
 func (p *Package) EnsureRefType(name string) (RefType, bool) {
-	refType, ok := p.RefTypeByName(name)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	refType, ok := p.refTypeMap[name]
 	if ok {
 		return refType, true
 	}
 	refType = NewRefType(name, p, nil)
-	p.AddRefType(refType)
+	p.addRefType(refType)
 	return refType, false
 }
 
 // RefTypeByName find RefType by name.
 func (p *Package) RefTypeByName(name string) (RefType, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
 	refType, ok := p.refTypeMap[name]
 	return refType, ok
 }
 
 func (p *Package) AddRefType(ref RefType) RefType {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.addRefType(ref)
+}
+
+func (p *Package) addRefType(ref RefType) RefType {
 	if ref.Name() != "" {
 		p.RefType = append(p.RefType, ref)
 		p.refTypeMap[ref.Name()] = ref
@@ -453,16 +752,22 @@ func (p *Package) AppendRefType(name string) (ref RefType) {
 }
 
 func (p *Package) AppendMethod(method *MethodDescriptor) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	p.Methods = append(p.Methods, method)
 	p.MethodsMap[method.Name()] = method
 }
 
 func (p *Package) MethodByName(name string) (*MethodDescriptor, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
 	m, ok := p.MethodsMap[name]
 	return m, ok
 }
 
 func (p *Package) VariableByName(name string) (vrle *Variable) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
 	for _, v := range p.Variables {
 		if v.Name == name {
 			return v
@@ -472,10 +777,32 @@ func (p *Package) VariableByName(name string) (vrle *Variable) {
 }
 
 func (p *Package) AppendVariable(variable *Variable) *Variable {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	p.Variables = append(p.Variables, variable)
 	return variable
 }
 
+// ConstantByName find Constant by name.
+func (p *Package) ConstantByName(name string) (*Constant, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	for _, c := range p.Constants {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// AppendConstant registers a new Constant to the package.
+func (p *Package) AppendConstant(c *Constant) *Constant {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.Constants = append(p.Constants, c)
+	return c
+}
+
 // AppendTagParam add new TagParam in Tag
 func (t *Tag) AppendTagParam(tNew *TagParam) bool {
 	tp := t.TagParamByName(tNew.Name)
@@ -0,0 +1,106 @@
+package myasthurts
+
+import "go/ast"
+
+// Enum represents a named type with the constants declared with it. Ex:
+//
+//	type Kind int
+//
+//	const (
+//		KindA Kind = iota
+//		KindB
+//	)
+type Enum struct {
+	Type *NamedType
+	// Constants are the members of the enum, in declaration order.
+	Constants []*Constant
+	// Aliases are the constants defined as other members, which are not
+	// members themselves. Ex: `const DefaultKind = KindA`.
+	Aliases []*Constant
+}
+
+// Enums returns the named types of the package that have typed constants
+// declared with them. Enums follow the declaration order of the types.
+func (p *Package) Enums() []*Enum {
+	p.mu.RLock()
+	namedTypes, constants := p.NamedTypes, p.Constants
+	p.mu.RUnlock()
+
+	enumsMap := make(map[*NamedType]*Enum, len(namedTypes))
+	for _, t := range namedTypes {
+		enumsMap[t] = &Enum{
+			Type: t,
+		}
+	}
+
+	aliases := make([]*Constant, 0)
+	for _, c := range constants {
+		enum, ok := constantEnum(enumsMap, c)
+		if !ok {
+			continue
+		}
+		if aliased, ok := aliasedConstant(c); ok {
+			if aliasedEnum, ok := constantEnum(enumsMap, aliased); ok && aliasedEnum == enum {
+				aliases = append(aliases, c)
+				continue
+			}
+		}
+		enum.Constants = append(enum.Constants, c)
+	}
+	for _, c := range aliases {
+		enum, _ := constantEnum(enumsMap, c)
+		enum.Aliases = append(enum.Aliases, c)
+	}
+
+	enums := make([]*Enum, 0)
+	for _, t := range namedTypes {
+		if enum := enumsMap[t]; len(enum.Constants) > 0 {
+			enums = append(enums, enum)
+		}
+	}
+	return enums
+}
+
+// constantEnum returns the enum of the constant, if it is typed with one of
+// the named types of the package. Constants of named types from other
+// packages are not members.
+func constantEnum(enumsMap map[*NamedType]*Enum, c *Constant) (*Enum, bool) {
+	if !c.typed {
+		return nil, false
+	}
+	named, ok := Unalias(c.RefType).Type().(*NamedType)
+	if !ok {
+		return nil, false
+	}
+	enum, ok := enumsMap[named]
+	return enum, ok
+}
+
+// aliasedConstant returns the constant the given constant is defined as,
+// following other aliases. Ex: `KindA` for `const DefaultKind = KindA`. Only
+// the constants of the package are followed, so it stops at `iota` (Ex: the
+// implicit `iota` of `KindB` in `KindA Kind = iota; KindB`).
+func aliasedConstant(c *Constant) (*Constant, bool) {
+	aliased, ok := c, false
+	// The limit protects against invalid cyclic declarations.
+	for i := 0; i < 100 && aliased.ctx != nil; i++ {
+		expr := aliased.expr
+		for {
+			paren, ok := expr.(*ast.ParenExpr)
+			if !ok {
+				break
+			}
+			expr = paren.X
+		}
+		ident, isIdent := expr.(*ast.Ident)
+		if !isIdent || ident.Name == "iota" {
+			break
+		}
+		next, found := aliased.ctx.Package.ConstantByName(ident.Name)
+		if !found || next == aliased {
+			break
+		}
+		aliased, ok = next, true
+	}
+	return aliased, ok
+}
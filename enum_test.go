@@ -0,0 +1,82 @@
+package myasthurts_test
+
+import (
+	"go/constant"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	myasthurts "github.com/jamillosantos/go-my-ast-hurts"
+)
+
+var _ = Describe("Enum", func() {
+	Describe("Enums", func() {
+		It("should find enums declared in the package", func() {
+			env, err := myasthurts.NewEnvironment()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(env.ParseFile(newDataPackageContext(env), "data/models21.sample.go")).To(Succeed())
+
+			pkg, ok := env.PackageByImportPath("data")
+			Expect(ok).To(BeTrue())
+
+			enums := pkg.Enums()
+			Expect(enums).To(HaveLen(2))
+
+			Expect(enums[0].Type.Name()).To(Equal("Color"))
+			Expect(enums[0].Constants).To(HaveLen(3))
+			Expect(enums[0].Constants[0].Name).To(Equal("ColorRed"))
+			Expect(enums[0].Constants[0].Doc.Comments).To(HaveLen(1))
+			Expect(enums[0].Constants[0].Value.ExactString()).To(Equal("1"))
+			Expect(enums[0].Constants[1].Name).To(Equal("ColorGreen"))
+			Expect(enums[0].Constants[2].Name).To(Equal("ColorBlue"))
+			Expect(enums[0].Constants[2].Value.ExactString()).To(Equal("3"))
+			Expect(enums[0].Aliases).To(HaveLen(2))
+			Expect(enums[0].Aliases[0].Name).To(Equal("DefaultColor"))
+			Expect(enums[0].Aliases[0].Value.ExactString()).To(Equal("2"))
+			Expect(enums[0].Aliases[1].Name).To(Equal("FallbackColor"))
+
+			Expect(enums[1].Type.Name()).To(Equal("Size"))
+			Expect(enums[1].Constants).To(HaveLen(3))
+			Expect(enums[1].Constants[2].Name).To(Equal("SizeLarge"))
+			Expect(enums[1].Aliases).To(BeEmpty())
+			Expect(constant.StringVal(enums[1].Constants[0].Value)).To(Equal("S"))
+			Expect(constant.StringVal(enums[1].Constants[1].Value)).To(Equal("M"))
+		})
+
+		It("should not list the aliases of iota members as members", func() {
+			env, err := myasthurts.NewEnvironment()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(env.ParseFile(newDataPackageContext(env), "data/models36.sample.go")).To(Succeed())
+
+			pkg, ok := env.PackageByImportPath("data")
+			Expect(ok).To(BeTrue())
+
+			enums := pkg.Enums()
+			Expect(enums).To(HaveLen(1))
+			names := func(constants []*myasthurts.Constant) []string {
+				r := make([]string, len(constants))
+				for i, c := range constants {
+					r[i] = c.Name
+				}
+				return r
+			}
+			Expect(names(enums[0].Constants)).To(Equal([]string{"K0", "K1", "K2", "Last"}))
+			Expect(names(enums[0].Aliases)).To(Equal([]string{"Default", "Other"}))
+			Expect(enums[0].Aliases[0].Value.ExactString()).To(Equal("1"))
+		})
+
+		It("should return no enums when there are no typed constants", func() {
+			env, err := myasthurts.NewEnvironment()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(env.ParseFile(newDataPackageContext(env), "data/models17.sample.go")).To(Succeed())
+
+			pkg, ok := env.PackageByImportPath("data")
+			Expect(ok).To(BeTrue())
+
+			Expect(pkg.Enums()).To(BeEmpty())
+		})
+	})
+})
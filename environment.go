@@ -1,14 +1,18 @@
 package myasthurts
 
 import (
-	"errors"
 	"fmt"
 	"go/ast"
 	"go/build"
 	"go/parser"
 	"go/token"
+	"go/types"
 	"os"
-	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
 )
 
 // ParsePackageContext keeps all information needed for parsing a package.
@@ -19,6 +23,21 @@ type ParsePackageContext struct {
 	// Package is what is being parsed. It will keep all information for what is
 	// being parsed.
 	Package *Package
+
+	// files are the paths of the files of the package to be parsed.
+	files []string
+	// parsed keeps the ASTs of the files, parsed ahead of building the
+	// models (see Environment.preparePackage).
+	parsed *parsedFiles
+	// info is the information from the type checker, when
+	// EnvConfig.TypeCheck is set.
+	info *types.Info
+	// platforms maps the path of the files to the platforms they are built
+	// for, when EnvConfig.Platforms is set.
+	platforms map[string][]Platform
+	// tests tells if the test files of the package are parsed, see
+	// Environment.packageContext.
+	tests bool
 }
 
 func NewPackageContext(pkg *Package, buildPackage *build.Package) *ParsePackageContext {
@@ -36,6 +55,14 @@ type ParseFileContext struct {
 	Package               *Package
 	dotImports            []*Package
 	packageImportAliasMap map[string]*Package
+	// typeParams keeps the type parameters in the scope of the declaration
+	// being parsed.
+	typeParams map[string]RefType
+	// file is the model of the file being parsed.
+	file *File
+	// info is the information from the type checker. It is nil unless
+	// EnvConfig.TypeCheck is set.
+	info *types.Info
 }
 
 func (ctx *ParseFileContext) PackageByImportAlias(name string) (*Package, bool) {
@@ -46,8 +73,18 @@ func (ctx *ParseFileContext) PackageByImportAlias(name string) (*Package, bool)
 // GetRefType will return a type defined on the context or in the dot imported
 // libraries. If no file exists, it will return an `ErrTypeNotFound`.
 func (ctx *ParseFileContext) GetRefType(name string) (RefType, bool) {
-	// First, it tries to find the type on its own package.
-	if t, ok := ctx.Package.RefTypeByName(name); ok {
+	// Type parameters shadow any other declaration.
+	if t, ok := ctx.typeParams[name]; ok {
+		return t, true
+	}
+
+	// Then, it tries to find the type on its own package, as declared for
+	// the platforms of the file.
+	var platforms []Platform
+	if ctx.file != nil {
+		platforms = ctx.file.Platforms
+	}
+	if t, ok := ctx.Package.refTypeForPlatforms(name, platforms); ok {
 		return t, true
 	}
 
@@ -62,6 +99,23 @@ func (ctx *ParseFileContext) GetRefType(name string) (RefType, bool) {
 	return nil, false
 }
 
+// scopeTypeParams adds the given type parameters to the context scope. The
+// returned function restores the previous scope.
+func (ctx *ParseFileContext) scopeTypeParams(typeParams map[string]RefType) func() {
+	previous := ctx.typeParams
+	scope := make(map[string]RefType, len(previous)+len(typeParams))
+	for name, ref := range previous {
+		scope[name] = ref
+	}
+	for name, ref := range typeParams {
+		scope[name] = ref
+	}
+	ctx.typeParams = scope
+	return func() {
+		ctx.typeParams = previous
+	}
+}
+
 // Environment is the virtual representation of a Go environment.
 type Environment struct {
 	// BuildContext is the reference of the build context used to extract
@@ -82,13 +136,46 @@ type Environment struct {
 	// Listener implement a set of interfaces that let the developer to have
 	// some control over how files are parsed.
 	Listener interface{}
+
+	// module is the main module, found from the current directory. It is
+	// nil if the current directory is not inside of a module.
+	module *Module
+	// moduleFrom is the directory used for finding the main module.
+	moduleFrom string
+
+	// importer type checks the dependencies of the packages when
+	// EnvConfig.TypeCheck is set.
+	importer     *sourceImporter
+	importerOnce sync.Once
+
+	// mu guards the packages and the main module, so they can be read while
+	// other goroutines are parsing.
+	mu sync.RWMutex
+	// parseMu serializes building the models. Files are parsed in parallel
+	// before it is acquired, but only one goroutine changes the models at a
+	// time. It is released while the listeners run, so they can parse other
+	// packages.
+	parseMu sync.Mutex
+	// parsing keeps the packages parsed directly that are not in the
+	// environment yet, they are added when they are parsed. It is guarded by
+	// parseMu.
+	parsing map[string]*Package
 }
 
 func NewEnvironment() (*Environment, error) {
+	return NewEnvironmentWithConfig(EnvConfig{})
+}
+
+// NewEnvironmentWithConfig creates an environment with the given
+// configuration. Differently from changing Environment.Config later, the
+// configuration is used for parsing the builtin package too.
+func NewEnvironmentWithConfig(config EnvConfig) (*Environment, error) {
 	env := &Environment{
 		packages:     make([]*Package, 0, 5),
 		packageMap:   make(map[string]*Package, 5),
+		parsing:      make(map[string]*Package),
 		BuildContext: build.Default,
+		Config:       config,
 	}
 
 	if err := env.makeEnv(); err != nil {
@@ -106,45 +193,190 @@ func NewEnvironmentWithListener(listener interface{}) (*Environment, error) {
 	return env, nil
 }
 
+// Import finds the package with the given import path. The standard library,
+// the main module (see `Module`), its vendor directory, the replace directives
+// and the module cache are considered, in this order. Packages not found are
+// looked up using the GOPATH.
 func (env *Environment) Import(importPathPkg string) (*build.Package, error) {
-	d := "."
-	if env.Config.CurrentDir != "" {
-		d = env.Config.CurrentDir
+	return env.importContext(env.BuildContext, importPathPkg)
+}
+
+// importContext finds the package with the given import path, selecting its
+// files with the given build context.
+func (env *Environment) importContext(buildCtx build.Context, importPathPkg string) (*build.Package, error) {
+	dir, err := env.importPathDir(importPathPkg)
+	if err != nil {
+		return nil, err
+	}
+	if dir == "" {
+		d := "."
+		if env.Config.CurrentDir != "" {
+			d = env.Config.CurrentDir
+		}
+		// Setting any of the file system hooks makes go/build use only the
+		// GOPATH, instead of calling `go list` (that may download modules).
+		buildCtx.JoinPath = filepath.Join
+		return buildCtx.Import(importPathPkg, d, build.ImportComment)
 	}
-	buildPkg, err := env.BuildContext.Import(importPathPkg, d, build.ImportComment)
+
+	buildPkg, err := buildCtx.ImportDir(dir, build.ImportComment)
 	if err != nil {
 		return nil, err
 	}
+	buildPkg.ImportPath = importPathPkg
 	return buildPkg, nil
 }
 
+// ImportDir finds the package in the given directory. Directories inside of
+// the main module get their import paths from the module path.
 func (env *Environment) ImportDir(importDir string) (*build.Package, error) {
 	buildPkg, err := env.BuildContext.ImportDir(importDir, build.ImportComment)
 	if err != nil {
 		return nil, err
 	}
+	if buildPkg.Goroot {
+		return buildPkg, nil
+	}
+	module, err := env.Module()
+	if err == ErrModuleNotFound {
+		return buildPkg, nil
+	} else if err != nil {
+		return nil, err
+	}
+	absDir, err := filepath.Abs(importDir)
+	if err != nil {
+		return nil, err
+	}
+	if importPath, ok := module.importPathFor(absDir); ok {
+		buildPkg.ImportPath = importPath
+	}
 	return buildPkg, nil
 }
 
+// Module returns the main module, the one with the go.mod file found in the
+// current directory (see EnvConfig.CurrentDir) or in its parents. It returns
+// ErrModuleNotFound if there is no main module.
+func (env *Environment) Module() (*Module, error) {
+	from := env.Config.CurrentDir
+	if from == "" {
+		from = "."
+	}
+	from, err := filepath.Abs(from)
+	if err != nil {
+		return nil, err
+	}
+	env.mu.Lock()
+	defer env.mu.Unlock()
+	if env.moduleFrom != from {
+		module, err := FindModule(from)
+		if err != nil && err != ErrModuleNotFound {
+			return nil, err
+		}
+		env.module, env.moduleFrom = module, from
+	}
+	if env.module == nil {
+		return nil, ErrModuleNotFound
+	}
+	return env.module, nil
+}
+
+// importPathDir returns the directory of the package with the given import
+// path, without using the GOPATH. It returns an empty string if the package
+// was not found.
+func (env *Environment) importPathDir(importPath string) (string, error) {
+	goroot := env.BuildContext.GOROOT
+	if _, ok := dirIfExists(goroot); !ok {
+		return "", errors.Errorf("go/build: cannot find GOROOT directory: %s", goroot)
+	}
+	src := filepath.Join(goroot, "src")
+
+	if dir, ok := dirIfExists(filepath.Join(src, filepath.FromSlash(importPath))); ok {
+		return dir, nil
+	}
+
+	module, err := env.Module()
+	if err != nil && err != ErrModuleNotFound {
+		return "", err
+	}
+	if module != nil {
+		if dir, ok := module.moduleDir(importPath, env.moduleCache()); ok {
+			return dir, nil
+		}
+	}
+
+	// Dependencies of the standard library are vendored. Ex:
+	// golang.org/x/net/dns/dnsmessage
+	if dir, ok := dirIfExists(filepath.Join(src, "vendor", filepath.FromSlash(importPath))); ok {
+		return dir, nil
+	}
+	return "", nil
+}
+
+// moduleCache returns the directory of the module cache (see
+// EnvConfig.ModCache).
+func (env *Environment) moduleCache() string {
+	if env.Config.ModCache != "" {
+		return env.Config.ModCache
+	}
+	if dir := os.Getenv("GOMODCACHE"); dir != "" {
+		return dir
+	}
+	gopath := filepath.SplitList(env.BuildContext.GOPATH)
+	if len(gopath) == 0 || gopath[0] == "" {
+		return ""
+	}
+	return filepath.Join(gopath[0], "pkg", "mod")
+}
+
 // PackageByImportPath find Package by name in Environment.
 func (env *Environment) PackageByImportPath(importPath string) (*Package, bool) {
+	env.mu.RLock()
+	defer env.mu.RUnlock()
 	pkg, ok := env.packageMap[importPath]
 	return pkg, ok
 }
 
 // AppendPackage add new Package in Environment.
 func (env *Environment) AppendPackage(pkg *Package) {
+	env.mu.Lock()
+	defer env.mu.Unlock()
+	env.appendPackage(pkg)
+}
+
+func (env *Environment) appendPackage(pkg *Package) {
+	pkg.env = env
 	env.packages = append(env.packages, pkg)
 	env.packageMap[pkg.ImportPath] = pkg
 }
 
-// parsePackage will list all files for a package and
+// ensurePackage adds the package to the environment, unless there is a
+// package with the same import path. It returns the package in the
+// environment.
+func (env *Environment) ensurePackage(pkg *Package) *Package {
+	env.mu.Lock()
+	defer env.mu.Unlock()
+	if p, ok := env.packageMap[pkg.ImportPath]; ok {
+		return p
+	}
+	env.appendPackage(pkg)
+	return pkg
+}
+
+// parsePackage will list all files for a package and parse them. It must be
+// called with parseMu locked.
 func (env *Environment) parsePackage(pkgCtx *ParsePackageContext) error {
-	for _, file := range pkgCtx.BuildPackage.GoFiles {
-		filePath := path.Join(pkgCtx.Package.RealPath, file)
+	pkgCtx.Package.addLoading(1)
+	defer pkgCtx.Package.addLoading(-1)
+
+	if err := env.preparePackage(pkgCtx); err != nil {
+		return err
+	}
 
+	for _, filePath := range pkgCtx.files {
 		if beforeFileListener, ok := env.Listener.(ListenerBeforeFile); ok {
-			err := beforeFileListener.BeforeFile(pkgCtx, filePath)
+			err := env.unlocked(func() error {
+				return beforeFileListener.BeforeFile(pkgCtx, filePath)
+			})
 			if err == Skip { // Shall the file be skipped?
 				continue
 			} else if err != nil { // This is an actual error...
@@ -152,9 +384,11 @@ func (env *Environment) parsePackage(pkgCtx *ParsePackageContext) error {
 			}
 		}
 
-		err := env.ParseFile(pkgCtx, filePath)
+		err := env.parseFile(pkgCtx, filePath)
 		if fileListener, ok := env.Listener.(ListenerAfterFile); ok {
-			errAfterFile := fileListener.AfterFile(pkgCtx, filePath, err)
+			errAfterFile := env.unlocked(func() error {
+				return fileListener.AfterFile(pkgCtx, filePath, err)
+			})
 			if err != nil {
 				return errAfterFile
 			}
@@ -164,63 +398,197 @@ func (env *Environment) parsePackage(pkgCtx *ParsePackageContext) error {
 		}
 	}
 	pkgCtx.Package.Explored = true
+
+	if pkgCtx.tests && len(pkgCtx.BuildPackage.XTestGoFiles) > 0 {
+		return env.parseXTest(pkgCtx)
+	}
 	return nil
 }
 
+// unlocked calls fn with parseMu released. The packages being parsed keep
+// loading meanwhile, so they are not parsed again (see Package.resolveLocked).
+func (env *Environment) unlocked(fn func() error) error {
+	env.parseMu.Unlock()
+	defer env.parseMu.Lock()
+	return fn()
+}
+
+// includeTests checks if the test files of the package should be parsed.
+// Only the packages parsed directly have their tests parsed.
+func (env *Environment) includeTests(pkg *Package) bool {
+	return env.Config.IncludeTests && pkg.depth == 0 && pkg.ForTest == nil
+}
+
+// packageContext returns the context for parsing the package. It must be
+// called with parseMu locked. The test files to parse are decided here, as the
+// depth of the package may change while it is prepared without the lock.
+func (env *Environment) packageContext(pkg *Package, buildPkg *build.Package) *ParsePackageContext {
+	pkgCtx := NewPackageContext(pkg, buildPkg)
+	pkgCtx.tests = env.includeTests(pkg)
+	return pkgCtx
+}
+
+// packageFiles returns the names of the Go files of the package, including
+// the test files when they are enabled. For external test packages, only the
+// external test files are returned.
+func (env *Environment) packageFiles(pkgCtx *ParsePackageContext, buildPkg *build.Package) []string {
+	if pkgCtx.Package.ForTest != nil {
+		return buildPkg.XTestGoFiles
+	}
+	if !pkgCtx.tests {
+		return buildPkg.GoFiles
+	}
+	files := make([]string, 0, len(buildPkg.GoFiles)+len(buildPkg.TestGoFiles))
+	files = append(files, buildPkg.GoFiles...)
+	return append(files, buildPkg.TestGoFiles...)
+}
+
+// parseXTest parses the external test package of the package being parsed.
+// The package under test is added to the environment, so the imports of the
+// external test package refer to it.
+func (env *Environment) parseXTest(pkgCtx *ParsePackageContext) error {
+	xtestCtx := env.xtestContext(pkgCtx.Package, *pkgCtx.BuildPackage)
+	if xtestCtx.Package.Explored {
+		return nil
+	}
+	return env.parsePackage(xtestCtx)
+}
+
+// xtestContext returns the context of the external test package of the
+// package, creating the package if it does not exist yet.
+func (env *Environment) xtestContext(pkg *Package, buildPkg build.Package) *ParsePackageContext {
+	pkg = env.ensurePackage(pkg)
+
+	buildPkg.Name += "_test"
+	buildPkg.ImportPath += "_test"
+
+	xtest := pkg.XTest
+	if xtest == nil {
+		xtest = NewPackage(&buildPkg)
+		xtest.ForTest = pkg
+		pkg.XTest = xtest
+		xtest = env.ensurePackage(xtest)
+	}
+	return env.packageContext(xtest, &buildPkg)
+}
+
+// ParseDir parses the package in the given directory. It is safe for
+// concurrent use. A package being parsed by another call (Ex: by a listener)
+// is returned as it is.
 func (env *Environment) ParseDir(dir string) (*Package, error) {
 	// Find the path of the package.
-	buildPkg, err := env.BuildContext.ImportDir(dir, build.ImportComment)
+	buildPkg, err := env.ImportDir(dir)
 	if err != nil {
 		return nil, err
 	}
+	packages, err := env.parseDirect([]*build.Package{buildPkg})
+	if err != nil {
+		return nil, err
+	}
+	return packages[0], nil
+}
 
-	p, ok := env.packageMap[buildPkg.ImportPath]
-	if ok { // If the package exists in the environment.
-		if p.Explored { // If the package is already explored.
-			return p, nil // just return it, no need to do anything.
+// parseDirect parses the packages directly, with their tests if they are
+// enabled. If a package exists in the environment, it is used. The packages
+// are prepared in parallel without parseMu, but the models are built in
+// order.
+func (env *Environment) parseDirect(buildPkgs []*build.Package) ([]*Package, error) {
+	pkgCtxs := make([]*ParsePackageContext, len(buildPkgs))
+	skip := make([]bool, len(buildPkgs))
+	env.parseMu.Lock()
+	for i, buildPkg := range buildPkgs {
+		p, ok := env.packageLocked(buildPkg.ImportPath)
+		if !ok {
+			p = NewPackage(buildPkg)
 		}
-		// The package will be explored down function.
-	} else {
-		p = NewPackage(buildPkg)
+		// Packages parsed directly may have been imported before.
+		p.depth = 0
+		pkgCtxs[i], skip[i] = env.packageContext(p, buildPkg), p.Explored || p.isLoading()
 	}
+	env.parseMu.Unlock()
 
-	err = env.parsePackage(NewPackageContext(p, buildPkg))
-	if err != nil {
-		return nil, err
+	errs := make([]error, len(pkgCtxs))
+	parallel(env.workers(), len(pkgCtxs), func(i int) {
+		if !skip[i] {
+			errs[i] = env.preparePackage(pkgCtxs[i])
+		}
+	})
+
+	env.parseMu.Lock()
+	defer env.parseMu.Unlock()
+	packages := make([]*Package, len(pkgCtxs))
+	for i, pkgCtx := range pkgCtxs {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		// The package may have been added while it was prepared.
+		if p, ok := env.packageLocked(pkgCtx.Package.ImportPath); ok && p != pkgCtx.Package {
+			pkgCtx.Package, p.depth = p, 0
+		}
+		if !pkgCtx.Package.Explored && !pkgCtx.Package.isLoading() {
+			if err := env.parseNew(pkgCtx); err != nil {
+				return nil, err
+			}
+		}
+		// If it was not defined before (the external tests may have defined
+		// it), define it now.
+		packages[i] = env.ensurePackage(pkgCtx.Package)
+	}
+	return packages, nil
+}
+
+// parseNew parses the package, keeping it in parsing while it is not in the
+// environment. So, the listeners and the imports find it. It must be called
+// with parseMu locked.
+func (env *Environment) parseNew(pkgCtx *ParsePackageContext) error {
+	importPath := pkgCtx.Package.ImportPath
+	if _, ok := env.PackageByImportPath(importPath); !ok {
+		env.parsing[importPath] = pkgCtx.Package
+		defer delete(env.parsing, importPath)
 	}
-	return p, nil
+	return env.parsePackage(pkgCtx)
+}
+
+// packageLocked finds the package in the environment or, if it is being
+// parsed directly, in parsing. It must be called with parseMu locked.
+func (env *Environment) packageLocked(importPath string) (*Package, bool) {
+	if p, ok := env.PackageByImportPath(importPath); ok {
+		return p, true
+	}
+	p, ok := env.parsing[importPath]
+	return p, ok
 }
 
 // Parse checks if the parse was already done, if not, it parses the package.
+// It is safe for concurrent use. A package being parsed by another call (Ex:
+// by a listener) is returned as it is.
 func (env *Environment) Parse(packageName string) (*Package, error) {
-	p, ok := env.packageMap[packageName]
-	if ok && p.Explored { // If the package exists in the environment and it was explored.
+	if p, ok := env.parsedPackage(packageName); ok {
 		return p, nil // just return it, no need to do anything.
 	}
 
-	ctx := &env.BuildContext
-
 	// Find the path of the package.
-	buildPkg, err := ctx.Import(packageName, ".", build.ImportComment)
+	buildPkg, err := env.Import(packageName)
 	if err != nil {
 		return nil, err
 	}
-
-	newPkg := p
-	if newPkg == nil {
-		newPkg = NewPackage(buildPkg)
-	}
-
-	pkgCtx := NewPackageContext(newPkg, buildPkg)
-	if err = env.parsePackage(pkgCtx); err != nil {
+	packages, err := env.parseDirect([]*build.Package{buildPkg})
+	if err != nil {
 		return nil, err
 	}
+	return packages[0], nil
+}
 
-	if p == nil { // If it was not defined before
-		env.AppendPackage(pkgCtx.Package) // define it now
+// parsedPackage returns the package if it exists in the environment and it
+// was explored, or it is being parsed.
+func (env *Environment) parsedPackage(importPath string) (*Package, bool) {
+	env.parseMu.Lock()
+	defer env.parseMu.Unlock()
+	p, ok := env.packageLocked(importPath)
+	if !ok || !p.Explored && !p.isLoading() {
+		return nil, false
 	}
-
-	return pkgCtx.Package, nil
+	return p, true
 }
 
 func (env *Environment) gorootSourceDir() (rtn string, exrr error) {
@@ -239,16 +607,36 @@ func (env *Environment) makeEnv() error {
 	return nil
 }
 
+// ParseFile parses a single file into the package of the context. It is
+// safe for concurrent use.
 func (env *Environment) ParseFile(pkgCtx *ParsePackageContext, filePath string) error {
+	env.parseMu.Lock()
+	defer env.parseMu.Unlock()
+	return env.parseFile(pkgCtx, filePath)
+}
+
+func (env *Environment) parseFile(pkgCtx *ParsePackageContext, filePath string) error {
 	var (
 		file *ast.File
 		fset *token.FileSet
 		err  error
 	)
 
-	fset = token.NewFileSet()
-	if file, err = parser.ParseFile(fset, filePath, nil, parser.ParseComments); err != nil {
+	// Types referenced before their declaration must not resolve the package
+	// being parsed.
+	pkgCtx.Package.addLoading(1)
+	defer pkgCtx.Package.addLoading(-1)
+
+	var info *types.Info
+	if parsed, ok, err := pkgCtx.parsed.file(filePath); err != nil {
 		return err
+	} else if ok {
+		fset, file, info = pkgCtx.parsed.fset, parsed, pkgCtx.info
+	} else {
+		fset = token.NewFileSet()
+		if file, err = parser.ParseFile(fset, filePath, nil, parser.ParseComments); err != nil {
+			return err
+		}
 	}
 
 	dotImports := make([]*Package, 0, 1)
@@ -257,6 +645,23 @@ func (env *Environment) ParseFile(pkgCtx *ParsePackageContext, filePath string)
 		dotImports = append(dotImports, env.BuiltIn)
 	} // If it is not defined, it means we are parsing the builtin package.
 
+	fileModel := NewFile(pkgCtx.Package, filePath)
+	fileModel.Name = file.Name.Name
+	fileModel.Platforms = pkgCtx.platforms[filePath]
+	fileModel.Test = strings.HasSuffix(filePath, "_test.go")
+	if file.Doc != nil {
+		comments, err := parseComments(file.Doc)
+		if err != nil {
+			return err
+		}
+		fileModel.Doc = Doc{
+			Comments: comments,
+		}
+	}
+	if fileModel.Constraint, err = parseBuildConstraint(file); err != nil {
+		return errors.Wrapf(err, "invalid build constraint on %s", filePath)
+	}
+
 	// Create the context of the file parse.
 	fileCtx := &ParseFileContext{
 		File:                  file,
@@ -265,6 +670,8 @@ func (env *Environment) ParseFile(pkgCtx *ParsePackageContext, filePath string)
 		Package:               pkgCtx.Package,
 		dotImports:            dotImports,
 		packageImportAliasMap: make(map[string]*Package),
+		file:                  fileModel,
+		info:                  info,
 	}
 
 	// Prints the AST, if configured.
@@ -296,5 +703,13 @@ func (env *Environment) ParseFile(pkgCtx *ParsePackageContext, filePath string)
 		}
 
 	}
+
+	// Constants referring to declarations made after them are evaluated now.
+	fileCtx.Package.resolveConstants()
+	if err := fileCtx.Package.resolveArrayLens(); err != nil {
+		return err
+	}
+	fileCtx.Package.resolveEmbeds()
+	fileCtx.Package.AppendFile(fileModel)
 	return nil
 }
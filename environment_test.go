@@ -63,7 +63,7 @@ var _ = Describe("Environment", func() {
 
 			exploredPkg := &myasthurts.Package{
 				Name:       "models",
-				ImportPath: ".",
+				ImportPath: "github.com/jamillosantos/go-my-ast-hurts/data/parse_dir",
 				Explored:   true, // Faking an explored package.
 			}
 
@@ -8,6 +8,11 @@ var (
 	ErrPackageAliasNotFound     = errors.New("package alias not found")
 	ErrUnexpectedSelector       = errors.New("unexpected selector identifier")
 	ErrUnexpectedExpressionType = errors.New("unexpected expression type")
+	ErrModuleNotFound           = errors.New("go.mod not found")
+	ErrInvalidModFile           = errors.New("invalid go.mod file")
+	ErrPackageNotFound          = errors.New("package not found")
+	ErrFileNotFound             = errors.New("file not found")
+	ErrInvalidArrayLen          = errors.New("invalid array length")
 
 	// Skip will cancel the action.
 	Skip = errors.New("skip action")
@@ -316,13 +316,122 @@ var _ = Describe("Parse simples files with tags and func from struct", func() {
 			pkg, ok := env.PackageByImportPath("data")
 			Expect(ok).To(BeTrue())
 
-			a := pkg.VariableByName("PI")
-			Expect(a).ToNot(BeNil())
+			Expect(pkg.VariableByName("PI")).To(BeNil())
+
+			a, ok := pkg.ConstantByName("PI")
+			Expect(ok).To(BeTrue())
 			Expect(a.RefType).ToNot(BeNil())
+			Expect(a.RefType.Name()).To(Equal("float64"))
+			Expect(a.Value.String()).To(Equal("3.14"))
 
-			b := pkg.VariableByName("OLM")
-			Expect(b).ToNot(BeNil())
+			b, ok := pkg.ConstantByName("OLM")
+			Expect(ok).To(BeTrue())
 			Expect(b.RefType).ToNot(BeNil())
+			Expect(b.RefType.Name()).To(Equal("string"))
+			Expect(b.Expr).To(Equal(`"Olá Mundo Web"`))
+		})
+	})
+
+	When("parsing declarations with many names", func() {
+		It("should expand variables declared together", func() {
+			env, err := myasthurts.NewEnvironment()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(env.ParseFile(newDataPackageContext(env), "data/models20.sample.go")).To(Succeed())
+
+			pkg, ok := env.PackageByImportPath("data")
+			Expect(ok).To(BeTrue())
+
+			Expect(pkg.Variables).To(HaveLen(3))
+			x := pkg.VariableByName("x")
+			Expect(x).ToNot(BeNil())
+			y := pkg.VariableByName("y")
+			Expect(y).ToNot(BeNil())
+			Expect(x.RefType.Name()).To(Equal("int"))
+			Expect(y.RefType).To(Equal(x.RefType))
+			Expect(x.Doc.Comments).To(HaveLen(1))
+			Expect(y.Doc.Comments).To(Equal(x.Doc.Comments))
+		})
+
+		It("should expand struct fields declared together", func() {
+			env, err := myasthurts.NewEnvironment()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(env.ParseFile(newDataPackageContext(env), "data/models20.sample.go")).To(Succeed())
+
+			pkg, ok := env.PackageByImportPath("data")
+			Expect(ok).To(BeTrue())
+
+			point, ok := pkg.StructByName("Point")
+			Expect(ok).To(BeTrue())
+			Expect(point.Fields).To(HaveLen(3))
+			Expect(point.Fields[0].Name).To(Equal("X"))
+			Expect(point.Fields[1].Name).To(Equal("Y"))
+			Expect(point.Fields[2].Name).To(Equal("Label"))
+			Expect(point.Fields[1].RefType).To(Equal(point.Fields[0].RefType))
+			Expect(point.Fields[1].Tag.Raw).To(Equal(`json:"coord"`))
+			Expect(point.Fields[1].Doc.Comments).To(HaveLen(1))
+		})
+
+		It("should expand arguments and results declared together", func() {
+			env, err := myasthurts.NewEnvironment()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(env.ParseFile(newDataPackageContext(env), "data/models20.sample.go")).To(Succeed())
+
+			pkg, ok := env.PackageByImportPath("data")
+			Expect(ok).To(BeTrue())
+
+			move, ok := pkg.MethodByName("move")
+			Expect(ok).To(BeTrue())
+			Expect(move.Arguments).To(HaveLen(3))
+			Expect(move.Arguments[0].Name).To(Equal("p"))
+			Expect(move.Arguments[1].Name).To(Equal("dx"))
+			Expect(move.Arguments[2].Name).To(Equal("dy"))
+			Expect(move.Arguments[2].Type).To(Equal(move.Arguments[1].Type))
+			Expect(move.Result).To(HaveLen(2))
+			Expect(move.Result[0].Name).To(Equal("moved"))
+			Expect(move.Result[1].Name).To(Equal("err"))
+
+			mover, ok := pkg.InterfaceByName("Mover")
+			Expect(ok).To(BeTrue())
+			m := mover.MethodsMap()["Move"].Descriptor
+			Expect(m.Arguments).To(HaveLen(2))
+			Expect(m.Arguments[1].Name).To(Equal("dy"))
+			Expect(m.Result).To(HaveLen(2))
+			Expect(m.Result[1].Name).To(Equal("y"))
+		})
+
+		It("should accept unnamed receivers", func() {
+			env, err := myasthurts.NewEnvironment()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(env.ParseFile(newDataPackageContext(env), "data/models20.sample.go")).To(Succeed())
+
+			pkg, ok := env.PackageByImportPath("data")
+			Expect(ok).To(BeTrue())
+
+			point, ok := pkg.StructByName("Point")
+			Expect(ok).To(BeTrue())
+			Expect(point.Methods()).To(HaveLen(1))
+			Expect(point.Methods()[0].Name).To(Equal("Kind"))
+			Expect(point.Methods()[0].Descriptor.Recv[0].Name).To(BeEmpty())
+		})
+
+		It("should name type methods after the method, not the receiver", func() {
+			env, err := myasthurts.NewEnvironment()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(env.ParseFile(newDataPackageContext(env), "data/models10.sample.go")).To(Succeed())
+
+			pkg, ok := env.PackageByImportPath("data")
+			Expect(ok).To(BeTrue())
+
+			user, ok := pkg.RefTypeByName("User")
+			Expect(ok).To(BeTrue())
+			Expect(user.Type().Methods()).To(HaveLen(1))
+			Expect(user.Type().Methods()[0].Name).To(Equal("getName"))
+			Expect(user.Type().Methods()[0].Descriptor.Recv[0].Name).To(Equal("u"))
 		})
 	})
 
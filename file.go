@@ -0,0 +1,136 @@
+package myasthurts
+
+import (
+	"go/ast"
+	"go/build/constraint"
+)
+
+// File is utilized to represent each file read in Package.
+type File struct {
+	Package *Package
+	// FileName is the path of the file, as it was given to the parser.
+	FileName string
+	// Name is the package name declared by the package clause.
+	Name string
+	// Doc is the documentation of the package clause.
+	Doc Doc
+	// Constraint is the build constraint of the file. Ex: `linux && amd64`.
+	// It is nil when the file has no build constraints.
+	Constraint constraint.Expr
+	// Platforms the file is built for, see Platform.
+	Platforms []Platform
+	// Test tells if the file is a test file. Ex: `time_test.go`.
+	Test bool
+	// Imports holds the imports of the file, in the declaration order.
+	Imports    []*Import
+	Variables  []*Variable
+	Constants  []*Constant
+	Structs    []*Struct
+	Interfaces []*Interface
+	NamedTypes []*NamedType
+	Aliases    []*Alias
+	// Types holds all the types declared in the file, in the declaration
+	// order.
+	Types []Type
+	// Methods holds the functions and the methods declared in the file.
+	Methods []*MethodDescriptor
+	Files   []*File
+}
+
+// NewFile returns a new empty File.
+func NewFile(pkg *Package, fileName string) *File {
+	return &File{
+		Package:    pkg,
+		FileName:   fileName,
+		Imports:    make([]*Import, 0),
+		Variables:  make([]*Variable, 0),
+		Constants:  make([]*Constant, 0),
+		Structs:    make([]*Struct, 0),
+		Interfaces: make([]*Interface, 0),
+		NamedTypes: make([]*NamedType, 0),
+		Aliases:    make([]*Alias, 0),
+		Types:      make([]Type, 0),
+		Methods:    make([]*MethodDescriptor, 0),
+		Files:      make([]*File, 0),
+	}
+}
+
+// AppendImport appends an import of the file.
+func (f *File) AppendImport(i *Import) {
+	f.Imports = append(f.Imports, i)
+}
+
+// AppendVariable appends a variable declared in the file.
+func (f *File) AppendVariable(variable *Variable) {
+	variable.Platforms = f.Platforms
+	f.Variables = append(f.Variables, variable)
+}
+
+// AppendConstant appends a constant declared in the file.
+func (f *File) AppendConstant(c *Constant) {
+	c.Platforms = f.Platforms
+	f.Constants = append(f.Constants, c)
+}
+
+// AppendStruct appends a struct declared in the file.
+func (f *File) AppendStruct(s *Struct) {
+	s.Platforms = f.Platforms
+	f.Structs = append(f.Structs, s)
+	f.Types = append(f.Types, s)
+}
+
+// AppendInterface appends an interface declared in the file.
+func (f *File) AppendInterface(i *Interface) {
+	i.Platforms = f.Platforms
+	f.Interfaces = append(f.Interfaces, i)
+	f.Types = append(f.Types, i)
+}
+
+// AppendNamedType appends a named type declared in the file.
+func (f *File) AppendNamedType(t *NamedType) {
+	t.Platforms = f.Platforms
+	f.NamedTypes = append(f.NamedTypes, t)
+	f.Types = append(f.Types, t)
+}
+
+// AppendAlias appends an alias declared in the file.
+func (f *File) AppendAlias(alias *Alias) {
+	alias.Platforms = f.Platforms
+	f.Aliases = append(f.Aliases, alias)
+	f.Types = append(f.Types, alias)
+}
+
+// AppendMethod appends a function or a method declared in the file.
+func (f *File) AppendMethod(method *MethodDescriptor) {
+	method.Platforms = f.Platforms
+	f.Methods = append(f.Methods, method)
+}
+
+// parseBuildConstraint returns the build constraint of the file. `//go:build`
+// lines take precedence over `// +build` lines, that are combined as Go does.
+func parseBuildConstraint(file *ast.File) (constraint.Expr, error) {
+	var plusBuild constraint.Expr
+	for _, group := range file.Comments {
+		// Build constraints must appear before the package clause.
+		if group.Pos() >= file.Package {
+			break
+		}
+		for _, comment := range group.List {
+			switch {
+			case constraint.IsGoBuild(comment.Text):
+				return constraint.Parse(comment.Text)
+			case constraint.IsPlusBuild(comment.Text):
+				expr, err := constraint.Parse(comment.Text)
+				if err != nil {
+					return nil, err
+				}
+				if plusBuild == nil {
+					plusBuild = expr
+				} else {
+					plusBuild = &constraint.AndExpr{X: plusBuild, Y: expr}
+				}
+			}
+		}
+	}
+	return plusBuild, nil
+}
@@ -0,0 +1,88 @@
+package myasthurts_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	myasthurts "github.com/jamillosantos/go-my-ast-hurts"
+)
+
+var _ = Describe("File", func() {
+	var pkg *myasthurts.Package
+
+	BeforeEach(func() {
+		env, err := myasthurts.NewEnvironment()
+		Expect(err).ToNot(HaveOccurred())
+
+		pkgCtx := newDataPackageContext(env)
+		Expect(env.ParseFile(pkgCtx, "data/models30.sample.go")).To(Succeed())
+		Expect(env.ParseFile(pkgCtx, "data/models31.sample.go")).To(Succeed())
+
+		var ok bool
+		pkg, ok = env.PackageByImportPath("data")
+		Expect(ok).To(BeTrue())
+	})
+
+	It("should record each parsed file", func() {
+		Expect(pkg.Files).To(HaveLen(2))
+		Expect(pkg.Files[0].FileName).To(Equal("data/models30.sample.go"))
+		Expect(pkg.Files[0].Package).To(Equal(pkg))
+		Expect(pkg.Files[0].Name).To(Equal("models"))
+		Expect(pkg.Files[0].Doc.Comments).To(Equal([]string{"// Package models has the models of the application."}))
+		Expect(pkg.Files[1].FileName).To(Equal("data/models31.sample.go"))
+		Expect(pkg.Files[1].Doc.Comments).To(BeEmpty())
+	})
+
+	It("should record the declarations of the file", func() {
+		file := pkg.Files[0]
+
+		Expect(file.Imports).To(HaveLen(2))
+		Expect(file.Imports[0].Path).To(Equal("fmt"))
+		Expect(file.Imports[1].Path).To(Equal("time"))
+
+		Expect(file.Constants).To(HaveLen(1))
+		Expect(file.Constants[0].Name).To(Equal("Version"))
+		Expect(file.Variables).To(HaveLen(1))
+		Expect(file.Variables[0].Name).To(Equal("Started"))
+
+		Expect(file.Types).To(HaveLen(4))
+		Expect(file.Types[0].Name()).To(Equal("ID"))
+		Expect(file.Types[1].Name()).To(Equal("Status"))
+		Expect(file.Types[2].Name()).To(Equal("User"))
+		Expect(file.Types[3].Name()).To(Equal("Namer"))
+		Expect(file.Aliases).To(HaveLen(1))
+		Expect(file.NamedTypes).To(HaveLen(1))
+		Expect(file.Structs).To(HaveLen(1))
+		Expect(file.Interfaces).To(HaveLen(1))
+
+		Expect(file.Methods).To(HaveLen(2))
+		Expect(file.Methods[0].Name()).To(Equal("String"))
+		Expect(file.Methods[0].Recv).To(HaveLen(1))
+		Expect(file.Methods[1].Name()).To(Equal("NewUser"))
+
+		Expect(pkg.Files[1].Structs).To(HaveLen(1))
+		Expect(pkg.Files[1].Structs[0].Name()).To(Equal("Legacy"))
+	})
+
+	It("should parse the build constraints", func() {
+		Expect(pkg.Files[0].Constraint).ToNot(BeNil())
+		Expect(pkg.Files[0].Constraint.String()).To(Equal("linux && amd64"))
+		Expect(pkg.Files[0].Constraint.Eval(func(tag string) bool {
+			return tag == "linux" || tag == "amd64"
+		})).To(BeTrue())
+
+		Expect(pkg.Files[1].Constraint).ToNot(BeNil())
+		Expect(pkg.Files[1].Constraint.String()).To(Equal("(linux || darwin) && amd64"))
+	})
+
+	It("should not set build constraints for files without them", func() {
+		env, err := myasthurts.NewEnvironment()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(env.ParseFile(newDataPackageContext(env), "data/models1.sample.go")).To(Succeed())
+
+		pkg, ok := env.PackageByImportPath("data")
+		Expect(ok).To(BeTrue())
+		Expect(pkg.Files).To(HaveLen(1))
+		Expect(pkg.Files[0].Constraint).To(BeNil())
+	})
+})
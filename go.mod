@@ -1,15 +1,22 @@
 module github.com/jamillosantos/go-my-ast-hurts
 
-go 1.12
+go 1.18
 
 require (
 	github.com/fatih/structtag v1.2.0
-	github.com/kr/pretty v0.1.0 // indirect
-	github.com/onsi/ginkgo v1.16.5 // indirect
 	github.com/onsi/ginkgo/v2 v2.1.4
 	github.com/onsi/gomega v1.20.0
 	github.com/pkg/errors v0.9.1
+	golang.org/x/mod v0.14.0
+)
+
+require (
+	github.com/google/go-cmp v0.5.8 // indirect
+	github.com/kr/pretty v0.1.0 // indirect
+	github.com/onsi/ginkgo v1.16.5 // indirect
 	golang.org/x/net v0.0.0-20220811182439-13a9a731de15 // indirect
 	golang.org/x/sys v0.0.0-20220811171246-fbc7d0a398ab // indirect
+	golang.org/x/text v0.3.7 // indirect
 	gopkg.in/check.v1 v1.0.0-20190902080502-41f04d3bba15 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
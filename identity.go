@@ -0,0 +1,164 @@
+package myasthurts
+
+// Identical checks if two RefTypes refer to identical types, following the Go
+// type identity rules:
+//
+//   - Named types are identical only if they come from the same declaration;
+//   - Arrays are identical if they have the same length and identical
+//     element types;
+//   - Channels are identical if they have the same direction and identical
+//     element types;
+//   - Pointers, slices and variadic arguments are identical if their element
+//     types are identical;
+//   - Maps are identical if their key and value types are identical;
+//   - Functions are identical if they have identical arguments and results
+//     (names are not considered);
+//   - Anonymous structs are identical if their fields have the same names,
+//     tags, embedding and identical types;
+//   - Anonymous interfaces are identical if they have the same method set;
+//   - Generic instantiations are identical if they instantiate the same type
+//     with identical type arguments.
+//
+// Aliases are identical to the types they refer to.
+func Identical(a, b RefType) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	a, b = Unalias(a), Unalias(b)
+	if a == b {
+		return true
+	}
+
+	switch at := a.(type) {
+	case *StarRefType:
+		bt, ok := b.(*StarRefType)
+		return ok && Identical(at.RefType, bt.RefType)
+	case *SliceRefType:
+		bt, ok := b.(*SliceRefType)
+		return ok && Identical(at.RefType, bt.RefType)
+	case *ArrayRefType:
+		bt, ok := b.(*ArrayRefType)
+		return ok && at.Len == bt.Len && at.Len >= 0 && Identical(at.RefType, bt.RefType)
+	case *ChanRefType:
+		bt, ok := b.(*ChanRefType)
+		return ok && at.Dir == bt.Dir && Identical(at.RefType, bt.RefType)
+	case *EllipsisRefType:
+		bt, ok := b.(*EllipsisRefType)
+		return ok && Identical(at.RefType, bt.RefType)
+	case *InstanceRefType:
+		bt, ok := b.(*InstanceRefType)
+		if !ok || !Identical(at.RefType, bt.RefType) || len(at.TypeArgs) != len(bt.TypeArgs) {
+			return false
+		}
+		for i, arg := range at.TypeArgs {
+			if !Identical(arg, bt.TypeArgs[i]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if isComposite(b) {
+		return false
+	}
+	if isStructural(a) || isStructural(b) {
+		return identicalTypes(a.Type(), b.Type())
+	}
+	return identicalNames(a, b)
+}
+
+// isComposite checks if the RefType is composed by another RefType.
+func isComposite(ref RefType) bool {
+	switch ref.(type) {
+	case *StarRefType, *SliceRefType, *ArrayRefType, *ChanRefType, *EllipsisRefType, *InstanceRefType:
+		return true
+	}
+	return false
+}
+
+// isStructural checks if the RefType refers to a type literal. Ex: maps,
+// functions, anonymous structs and interfaces.
+func isStructural(ref RefType) bool {
+	if _, ok := ref.Type().(*MapType); ok {
+		return true
+	}
+	return ref.Name() == "" && ref.Type() != nil
+}
+
+// identicalNames checks if two RefTypes refer to the same named type.
+func identicalNames(a, b RefType) bool {
+	ta, tb := a.Type(), b.Type()
+	if ta != nil && tb != nil {
+		if _, ok := ta.(*BaseType); !ok {
+			return ta == tb
+		}
+	}
+	if a.Name() != b.Name() {
+		return false
+	}
+	pa, pb := a.Pkg(), b.Pkg()
+	if pa == nil || pb == nil {
+		return pa == pb
+	}
+	return pa == pb || pa.ImportPath == pb.ImportPath
+}
+
+// identicalTypes checks if two type literals are identical.
+func identicalTypes(a, b Type) bool {
+	switch at := a.(type) {
+	case *MapType:
+		bt, ok := b.(*MapType)
+		return ok && Identical(at.Key, bt.Key) && Identical(at.Value, bt.Value)
+	case *MethodDescriptor:
+		bt, ok := b.(*MethodDescriptor)
+		return ok && at.Compatible(bt)
+	case *Struct:
+		bt, ok := b.(*Struct)
+		if !ok || len(at.Fields) != len(bt.Fields) {
+			return false
+		}
+		for i, f := range at.Fields {
+			g := bt.Fields[i]
+			if f.Name != g.Name || f.Embedded != g.Embedded || f.Tag.Raw != g.Tag.Raw || !Identical(f.RefType, g.RefType) {
+				return false
+			}
+		}
+		return true
+	case *Interface:
+		bt, ok := b.(*Interface)
+		if !ok {
+			return false
+		}
+		am, bm := at.Methods(), bt.MethodsMap()
+		if len(am) != len(bm) || len(at.Unions) != len(bt.Unions) {
+			return false
+		}
+		for _, m := range am {
+			other, ok := bm[m.Descriptor.Name()]
+			if !ok || !m.Descriptor.Compatible(other.Descriptor) {
+				return false
+			}
+		}
+		for i, u := range at.Unions {
+			if !identicalTypes(u, bt.Unions[i]) {
+				return false
+			}
+		}
+		return true
+	case *Union:
+		bt, ok := b.(*Union)
+		if !ok || len(at.Terms) != len(bt.Terms) {
+			return false
+		}
+		for i, term := range at.Terms {
+			if term.Tilde != bt.Terms[i].Tilde || !Identical(term.RefType, bt.Terms[i].RefType) {
+				return false
+			}
+		}
+		return true
+	}
+	return a == b
+}
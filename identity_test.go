@@ -0,0 +1,100 @@
+package myasthurts_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	myasthurts "github.com/jamillosantos/go-my-ast-hurts"
+)
+
+var _ = Describe("Identical", func() {
+	var (
+		env *myasthurts.Environment
+		pkg *myasthurts.Package
+	)
+
+	BeforeEach(func() {
+		var err error
+		env, err = myasthurts.NewEnvironment()
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = env.Parse("io")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(env.ParseFile(newDataPackageContext(env), "data/models25.sample.go")).To(Succeed())
+
+		var ok bool
+		pkg, ok = env.PackageByImportPath("data")
+		Expect(ok).To(BeTrue())
+	})
+
+	method := func(structName, methodName string) *myasthurts.MethodDescriptor {
+		s, ok := pkg.StructByName(structName)
+		Expect(ok).To(BeTrue())
+		m, ok := s.MethodsMap()[methodName]
+		Expect(ok).To(BeTrue())
+		return m.Descriptor
+	}
+
+	It("should consider composite types with identical elements identical", func() {
+		save := method("UserRepository", "Save")
+		find := method("UserRepository", "Find")
+
+		Expect(save.Arguments[0].Type).ToNot(BeIdenticalTo(find.Result[0].Type))
+		Expect(myasthurts.Identical(save.Arguments[0].Type, find.Result[0].Type)).To(BeTrue())
+		Expect(myasthurts.Identical(save.Arguments[0].Type, save.Arguments[1].Type)).To(BeFalse())
+	})
+
+	It("should compare maps, channels and functions structurally", func() {
+		userFind := method("UserRepository", "Find")
+		idFind := method("IDRepository", "Find")
+		Expect(myasthurts.Identical(userFind.Arguments[1].Type, idFind.Arguments[1].Type)).To(BeTrue())
+		Expect(myasthurts.Identical(userFind.Arguments[0].Type, idFind.Arguments[0].Type)).To(BeFalse())
+
+		userEach := method("UserRepository", "Each")
+		idEach := method("IDRepository", "Each")
+		Expect(myasthurts.Identical(userEach.Arguments[0].Type, idEach.Arguments[0].Type)).To(BeTrue())
+	})
+
+	It("should distinguish named types from their underlying types", func() {
+		userSave := method("UserRepository", "Save")
+		idSave := method("IDRepository", "Save")
+		Expect(myasthurts.Identical(userSave.Arguments[0].Type, idSave.Arguments[0].Type)).To(BeFalse())
+		Expect(myasthurts.Identical(userSave.Arguments[1].Type, idSave.Arguments[1].Type)).To(BeTrue())
+	})
+
+	It("should consider aliases identical to the aliased type", func() {
+		read := method("Reader", "Read")
+
+		ioPkg, ok := env.PackageByImportPath("io")
+		Expect(ok).To(BeTrue())
+		reader, ok := ioPkg.InterfaceByName("Reader")
+		Expect(ok).To(BeTrue())
+
+		Expect(myasthurts.Identical(read.Arguments[0].Type, reader.Methods()[0].Descriptor.Arguments[0].Type)).To(BeTrue())
+	})
+
+	It("should check the implementation of interfaces with composite types", func() {
+		repository, ok := pkg.InterfaceByName("Repository")
+		Expect(ok).To(BeTrue())
+
+		userRepository, ok := pkg.StructByName("UserRepository")
+		Expect(ok).To(BeTrue())
+		Expect(userRepository.ImplementsPtr(repository)).To(BeTrue())
+
+		idRepository, ok := pkg.StructByName("IDRepository")
+		Expect(ok).To(BeTrue())
+		Expect(idRepository.ImplementsPtr(repository)).To(BeFalse())
+	})
+
+	It("should check the implementation of interfaces from other packages", func() {
+		ioPkg, ok := env.PackageByImportPath("io")
+		Expect(ok).To(BeTrue())
+		reader, ok := ioPkg.InterfaceByName("Reader")
+		Expect(ok).To(BeTrue())
+
+		s, ok := pkg.StructByName("Reader")
+		Expect(ok).To(BeTrue())
+		Expect(s.ImplementsPtr(reader)).To(BeTrue())
+		Expect(s.Implements(reader)).To(BeFalse())
+	})
+})
@@ -0,0 +1,31 @@
+package myasthurts
+
+// Import represents an import declared in a file.
+type Import struct {
+	// Path is the import path. Ex: `github.com/pkg/errors`.
+	Path string
+	// Alias is the name given to the import. Ex: `t` in `import t "time"`. It
+	// is empty if no name was given.
+	Alias string
+	// Dot is set for dot imports. Ex: `import . "time"`.
+	Dot bool
+	// Blank is set for imports made only for their side effects. Ex:
+	// `import _ "image/png"`.
+	Blank    bool
+	Doc      Doc
+	Position Position
+	// Package is the imported package.
+	Package *Package
+}
+
+// Name returns the identifier used to refer to the package in the file. It
+// is empty for dot and blank imports.
+func (i *Import) Name() string {
+	switch {
+	case i.Dot, i.Blank:
+		return ""
+	case i.Alias != "":
+		return i.Alias
+	}
+	return i.Package.Name
+}
@@ -0,0 +1,75 @@
+package myasthurts_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	myasthurts "github.com/jamillosantos/go-my-ast-hurts"
+)
+
+var _ = Describe("Import", func() {
+	var (
+		env  *myasthurts.Environment
+		file *myasthurts.File
+	)
+
+	BeforeEach(func() {
+		var err error
+		env, err = myasthurts.NewEnvironment()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(env.ParseFile(newDataPackageContext(env), "data/models32.sample.go")).To(Succeed())
+
+		pkg, ok := env.PackageByImportPath("data")
+		Expect(ok).To(BeTrue())
+		Expect(pkg.Files).To(HaveLen(1))
+		file = pkg.Files[0]
+	})
+
+	It("should record the imports of the file", func() {
+		Expect(file.Imports).To(HaveLen(4))
+
+		fmtImport := file.Imports[0]
+		Expect(fmtImport.Path).To(Equal("fmt"))
+		Expect(fmtImport.Alias).To(BeEmpty())
+		Expect(fmtImport.Name()).To(Equal("fmt"))
+		Expect(fmtImport.Dot).To(BeFalse())
+		Expect(fmtImport.Blank).To(BeFalse())
+		Expect(fmtImport.Position.Start.Line).To(Equal(4))
+
+		fmtPkg, ok := env.PackageByImportPath("fmt")
+		Expect(ok).To(BeTrue())
+		Expect(fmtImport.Package).To(Equal(fmtPkg))
+	})
+
+	It("should record blank imports", func() {
+		png := file.Imports[1]
+		Expect(png.Path).To(Equal("image/png"))
+		Expect(png.Alias).To(Equal("_"))
+		Expect(png.Blank).To(BeTrue())
+		Expect(png.Name()).To(BeEmpty())
+		Expect(png.Doc.Comments).To(Equal([]string{"// PNG decoder registration."}))
+		Expect(png.Package.Name).To(Equal("png"))
+	})
+
+	It("should record dot imports", func() {
+		strings := file.Imports[2]
+		Expect(strings.Path).To(Equal("strings"))
+		Expect(strings.Dot).To(BeTrue())
+		Expect(strings.Name()).To(BeEmpty())
+
+		pkg, ok := env.PackageByImportPath("data")
+		Expect(ok).To(BeTrue())
+		event, ok := pkg.StructByName("Event")
+		Expect(ok).To(BeTrue())
+		Expect(event.Fields[1].RefType.Pkg()).To(Equal(strings.Package))
+	})
+
+	It("should record aliased imports", func() {
+		time := file.Imports[3]
+		Expect(time.Path).To(Equal("time"))
+		Expect(time.Alias).To(Equal("t"))
+		Expect(time.Name()).To(Equal("t"))
+		Expect(time.Position.Start.Line).To(Equal(8))
+		Expect(time.Position.Start.Column).To(Equal(2))
+	})
+})
@@ -2,7 +2,19 @@ package myasthurts
 
 type Interface struct {
 	BaseType
-	Doc Doc
+	Doc        Doc
+	TypeParams []*TypeParam
+	// Unions holds the type elements of constraint interfaces. Ex:
+	// `~int | ~string`.
+	Unions []*Union
+	// Embeds holds the interfaces embedded by the interface. Ex: `io.Reader`.
+	// Embedded types that are not interfaces are type terms, added to Unions
+	// (Ex: `int` in `interface{ int }`). Types from packages not explored are
+	// kept here, as they cannot be told apart.
+	Embeds   []RefType
+	Position Position
+	// Platforms where the interface exists, see Platform.
+	Platforms []Platform
 }
 
 // NewInterface Create new Interface.
@@ -11,3 +23,106 @@ func NewInterface(pkg *Package, name string) *Interface {
 		BaseType: *NewBaseType(pkg, name),
 	}
 }
+
+// Methods returns the methods of the interface, including the methods of the
+// embedded interfaces. Methods declared by the interface itself come first.
+func (i *Interface) Methods() []*TypeMethod {
+	methods := make([]*TypeMethod, 0)
+	i.collectMethods(&methods, make(map[string]bool), make(map[*Interface]bool))
+	return methods
+}
+
+// MethodsMap returns the methods of the interface, including the methods of
+// the embedded interfaces, indexed by name.
+func (i *Interface) MethodsMap() map[string]*TypeMethod {
+	methods := i.Methods()
+	methodsMap := make(map[string]*TypeMethod, len(methods))
+	for _, m := range methods {
+		methodsMap[m.Descriptor.Name()] = m
+	}
+	return methodsMap
+}
+
+// collectMethods adds the methods of the interface, and the ones from its
+// embedded interfaces, that were not added yet.
+func (i *Interface) collectMethods(methods *[]*TypeMethod, names map[string]bool, visited map[*Interface]bool) {
+	if visited[i] {
+		return
+	}
+	visited[i] = true
+
+	for _, m := range i.BaseType.Methods() {
+		if names[m.Descriptor.Name()] {
+			continue
+		}
+		names[m.Descriptor.Name()] = true
+		*methods = append(*methods, m)
+	}
+
+	for _, embed := range i.Embeds {
+		// Embedded types that are not resolved do not add methods.
+		if embedded, ok := Unalias(embed).Type().(*Interface); ok {
+			embedded.collectMethods(methods, names, visited)
+		}
+	}
+}
+
+// addEmbedded adds the embedded type to Embeds or, if it is a type term, to
+// Unions as an Union with one term.
+func (i *Interface) addEmbedded(refType RefType) {
+	if term, _ := typeTerm(refType); term {
+		union := NewUnion(i.pkg)
+		union.Terms = append(union.Terms, &UnionTerm{RefType: refType})
+		i.Unions = append(i.Unions, union)
+		return
+	}
+	i.Embeds = append(i.Embeds, refType)
+}
+
+// pendingEmbeds checks if any of the embedded types is not resolved yet, so
+// it is not known whether it is an interface or a type term.
+func (i *Interface) pendingEmbeds() bool {
+	for _, embed := range i.Embeds {
+		if _, known := typeTerm(embed); !known {
+			return true
+		}
+	}
+	return false
+}
+
+// typeTerm checks if the embedded type is a type term instead of an
+// interface. Ex: `int` in `interface{ int }`. known is false while the type is
+// not resolved.
+func typeTerm(refType RefType) (term, known bool) {
+	refType = unaliasLocked(refType)
+	switch typeLocked(refType).(type) {
+	case nil:
+		// Predeclared types have no Type, see Package.AppendRefType.
+		pkg := refType.Pkg()
+		return pkg != nil && pkg.ImportPath == "builtin" && pkg.Explored, pkg != nil && pkg.Explored
+	case *Interface:
+		return false, true
+	case *BaseType: // Referred before its declaration.
+		return false, false
+	default:
+		return true, true
+	}
+}
+
+// resolveEmbeds moves the embedded types that turned out to be type terms to
+// the Unions of their interfaces. It runs after each file is parsed, as the
+// types can be declared by later files.
+func (p *Package) resolveEmbeds() {
+	pending := p.interfaces[:0]
+	for _, i := range p.interfaces {
+		embeds := i.Embeds
+		i.Embeds = nil
+		for _, embed := range embeds {
+			i.addEmbedded(embed)
+		}
+		if i.pendingEmbeds() {
+			pending = append(pending, i)
+		}
+	}
+	p.interfaces = pending
+}
@@ -12,6 +12,8 @@ var _ = Describe("Interface", func() {
 		It("should parse interfaces", func() {
 			env, err := myasthurts.NewEnvironment()
 			Expect(err).ToNot(HaveOccurred())
+			// io.Reader is resolved for its methods.
+			env.Config.Resolve = true
 
 			Expect(env.ParseFile(newDataPackageContext(env), "data/interface.go")).To(Succeed())
 
@@ -43,16 +45,68 @@ var _ = Describe("Interface", func() {
 			Expect(pkg.Interfaces[1].MethodsMap()).To(HaveKey("Age"))
 			Expect(pkg.Interfaces[1].MethodsMap()).To(HaveKey("SetAge"))
 			Expect(pkg.Interfaces[2].Name()).To(Equal("HasNameWrong"))
-			Expect(pkg.Interfaces[2].Methods()).To(HaveLen(2))
+			Expect(pkg.Interfaces[2].Embeds).To(HaveLen(1))
+			Expect(pkg.Interfaces[2].Embeds[0].Name()).To(Equal("Reader"))
+			Expect(pkg.Interfaces[2].Methods()).To(HaveLen(3))
 			Expect(pkg.Interfaces[2].Methods()[0].Descriptor.Name()).To(Equal("Name"))
 			Expect(pkg.Interfaces[2].Methods()[0].Descriptor.Arguments).To(BeEmpty())
 			Expect(pkg.Interfaces[2].Methods()[1].Descriptor.Name()).To(Equal("SetName"))
 			Expect(pkg.Interfaces[2].Methods()[1].Descriptor.Arguments).To(HaveLen(1))
 			Expect(pkg.Interfaces[2].Methods()[1].Descriptor.Arguments[0].Name).To(Equal("value"))
 			Expect(pkg.Interfaces[2].Methods()[1].Descriptor.Arguments[0].Type.Name()).To(Equal("int"))
-			Expect(pkg.Interfaces[2].MethodsMap()).To(HaveLen(2))
+			Expect(pkg.Interfaces[2].Methods()[2].Descriptor.Name()).To(Equal("Read"))
+			Expect(pkg.Interfaces[2].MethodsMap()).To(HaveLen(3))
 			Expect(pkg.Interfaces[2].MethodsMap()).To(HaveKey("Name"))
 			Expect(pkg.Interfaces[2].MethodsMap()).To(HaveKey("SetName"))
+			Expect(pkg.Interfaces[2].MethodsMap()).To(HaveKey("Read"))
+		})
+
+		It("should include methods of embedded interfaces", func() {
+			env, err := myasthurts.NewEnvironment()
+			Expect(err).ToNot(HaveOccurred())
+			env.Config.Resolve = true
+
+			Expect(env.ParseFile(newDataPackageContext(env), "data/models23.sample.go")).To(Succeed())
+
+			pkg, ok := env.PackageByImportPath("data")
+			Expect(ok).To(BeTrue())
+
+			io, ok := env.PackageByImportPath("io")
+			Expect(ok).To(BeTrue())
+			Expect(io.Explored).To(BeTrue())
+
+			closer, ok := pkg.InterfaceByName("Closer")
+			Expect(ok).To(BeTrue())
+			Expect(closer.Embeds).To(HaveLen(2))
+			Expect(closer.Embeds[0].Pkg()).To(Equal(io))
+			Expect(closer.Embeds[1].Pkg()).To(Equal(pkg))
+
+			resource, ok := pkg.InterfaceByName("Resource")
+			Expect(ok).To(BeTrue())
+			Expect(resource.Methods()).To(HaveLen(3))
+			Expect(resource.Methods()[0].Descriptor.Name()).To(Equal("ID"))
+			Expect(resource.Methods()[1].Descriptor.Name()).To(Equal("Close"))
+			Expect(resource.Methods()[2].Descriptor.Name()).To(Equal("Name"))
+			Expect(resource.MethodsMap()).To(HaveLen(3))
+		})
+
+		It("should not parse the packages of embedded interfaces unless resolving", func() {
+			env, err := myasthurts.NewEnvironment()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(env.ParseFile(newDataPackageContext(env), "data/models23.sample.go")).To(Succeed())
+
+			pkg, ok := env.PackageByImportPath("data")
+			Expect(ok).To(BeTrue())
+			io, ok := env.PackageByImportPath("io")
+			Expect(ok).To(BeTrue())
+			Expect(io.Explored).To(BeFalse())
+
+			closer, ok := pkg.InterfaceByName("Closer")
+			Expect(ok).To(BeTrue())
+			Expect(closer.Embeds).To(HaveLen(2))
+			Expect(closer.Embeds[0].Pkg()).To(Equal(io))
+			Expect(closer.Embeds[0].Type()).To(BeNil())
 		})
 	})
 })
@@ -2,23 +2,34 @@ package myasthurts
 
 // MethodArgument represent type of fields and arguments.
 type MethodArgument struct {
-	Name string
-	Type RefType
-	Doc  Doc
+	Name     string
+	Type     RefType
+	Doc      Doc
+	Position Position
 }
 
 type MethodDescriptor struct {
 	BaseType
-	Doc       Doc
-	Recv      []MethodArgument
-	Arguments []MethodArgument
-	Result    []MethodResult
-	Tag       Tag
+	Doc Doc
+	// TypeParams holds the type parameters of a generic function. For methods,
+	// it holds the type parameters declared by the receiver.
+	TypeParams []*TypeParam
+	Recv       []MethodArgument
+	// PointerReceiver is set for methods declared with a pointer receiver. Ex:
+	// `func (u *User) SetName(name string)`.
+	PointerReceiver bool
+	Arguments       []MethodArgument
+	Result          []MethodResult
+	Tag             Tag
+	Position        Position
+	// Platforms where the function exists, see Platform.
+	Platforms []Platform
 }
 
 type MethodResult struct {
-	Name string
-	Type RefType
+	Name     string
+	Type     RefType
+	Position Position
 }
 
 // NewMethodDescriptor return the pointer of new MethodDescriptor
@@ -40,8 +51,8 @@ func (method *MethodDescriptor) Name() string {
 
 // Compatible checks if the signature of both method descriptor are compatible.
 //
-// It checks if the all arguments refer to the same RefType. The same happens
-// with the result.
+// It checks if the all arguments have identical types (see `Identical`). The
+// same happens with the result.
 //
 // Receivers are not taken into consideration, neither names.
 func (method *MethodDescriptor) Compatible(m *MethodDescriptor) bool {
@@ -52,12 +63,78 @@ func (method *MethodDescriptor) Compatible(m *MethodDescriptor) bool {
 		return false
 	}
 	for i, arg := range method.Arguments {
-		if m.Arguments[i].Type != arg.Type {
+		if !Identical(m.Arguments[i].Type, arg.Type) {
 			return false
 		}
 	}
 	for i, r := range method.Result {
-		if m.Result[i].Type != r.Type {
+		if !Identical(m.Result[i].Type, r.Type) {
+			return false
+		}
+	}
+	return true
+}
+
+// MethodSet returns the method set of a type. For pointers (`*T`), it has all
+// methods declared with T or *T receivers. Otherwise, only methods declared
+// with value receivers are included. Methods promoted from embedded fields
+// follow the same rules.
+func MethodSet(ref RefType) []*TypeMethod {
+	// Aliases may stand for pointers. Ex: `type P = *T`.
+	ref = Unalias(ref)
+	pointer := false
+	if star, ok := ref.(*StarRefType); ok {
+		ref = Unalias(star.RefType)
+		pointer = true
+	}
+	if ref == nil || ref.Type() == nil {
+		return nil
+	}
+	return methodSet(ref.Type(), pointer)
+}
+
+func methodSet(t Type, pointer bool) []*TypeMethod {
+	switch tt := t.(type) {
+	case *Interface:
+		return tt.Methods()
+	case *Struct:
+		_, methods := tt.promote(pointer)
+		return methods
+	case *Alias:
+		if aliased := tt.Aliased(); aliased != nil {
+			return methodSet(aliased, pointer)
+		}
+		return nil
+	}
+
+	methods := make([]*TypeMethod, 0)
+	for _, m := range t.Methods() {
+		if pointer || !m.Descriptor.PointerReceiver {
+			methods = append(methods, m)
+		}
+	}
+	return methods
+}
+
+// Implements checks if the given type implements the interface. Pointers
+// (`*T`) and values (`T`) have different method sets, see `MethodSet`.
+func Implements(ref RefType, i *Interface) bool {
+	return implements(MethodSet(ref), i)
+}
+
+// implements checks if all methods of the interface are on the method set
+// with a compatible signature.
+func implements(methods []*TypeMethod, i *Interface) bool {
+	methodsMap := make(map[string]*TypeMethod, len(methods))
+	for _, m := range methods {
+		methodsMap[m.Descriptor.Name()] = m
+	}
+	for _, m := range i.Methods() {
+		method, ok := methodsMap[m.Descriptor.Name()]
+		if !ok {
+			return false
+		}
+		if !method.Descriptor.Compatible(m.Descriptor) {
 			return false
 		}
 	}
@@ -8,6 +8,93 @@ import (
 )
 
 var _ = Describe("MethodDescriptor", func() {
+	Describe("MethodSet", func() {
+		var pkg *myasthurts.Package
+
+		BeforeEach(func() {
+			env, err := myasthurts.NewEnvironment()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(env.ParseFile(newDataPackageContext(env), "data/models24.sample.go")).To(Succeed())
+
+			var ok bool
+			pkg, ok = env.PackageByImportPath("data")
+			Expect(ok).To(BeTrue())
+		})
+
+		methodNames := func(methods []*myasthurts.TypeMethod) []string {
+			names := make([]string, len(methods))
+			for i, m := range methods {
+				names[i] = m.Descriptor.Name()
+			}
+			return names
+		}
+
+		It("should record the receiver pointerness", func() {
+			value, ok := pkg.StructByName("Value")
+			Expect(ok).To(BeTrue())
+			Expect(value.MethodsMap()["String"].Descriptor.PointerReceiver).To(BeFalse())
+			Expect(value.MethodsMap()["Set"].Descriptor.PointerReceiver).To(BeTrue())
+		})
+
+		It("should distinguish value and pointer method sets", func() {
+			ref, ok := pkg.RefTypeByName("Value")
+			Expect(ok).To(BeTrue())
+
+			Expect(methodNames(myasthurts.MethodSet(ref))).To(Equal([]string{"String"}))
+			Expect(methodNames(myasthurts.MethodSet(myasthurts.NewStarRefType(ref)))).To(ConsistOf("String", "Set"))
+		})
+
+		It("should look through aliases of pointers", func() {
+			valuePtr, ok := pkg.RefTypeByName("ValuePtr")
+			Expect(ok).To(BeTrue())
+			Expect(methodNames(myasthurts.MethodSet(valuePtr))).To(ConsistOf("String", "Set"))
+
+			valueAlias, ok := pkg.RefTypeByName("ValueAlias")
+			Expect(ok).To(BeTrue())
+			Expect(methodNames(myasthurts.MethodSet(valueAlias))).To(Equal([]string{"String"}))
+			Expect(methodNames(myasthurts.MethodSet(myasthurts.NewStarRefType(valueAlias)))).To(ConsistOf("String", "Set"))
+
+			setter, ok := pkg.InterfaceByName("Setter")
+			Expect(ok).To(BeTrue())
+			Expect(myasthurts.Implements(valuePtr, setter)).To(BeTrue())
+		})
+
+		It("should promote pointer methods only through embedded pointers", func() {
+			byValue, ok := pkg.RefTypeByName("ByValue")
+			Expect(ok).To(BeTrue())
+			Expect(methodNames(myasthurts.MethodSet(byValue))).To(Equal([]string{"String"}))
+			Expect(methodNames(myasthurts.MethodSet(myasthurts.NewStarRefType(byValue)))).To(ConsistOf("String", "Set"))
+
+			byPointer, ok := pkg.RefTypeByName("ByPointer")
+			Expect(ok).To(BeTrue())
+			Expect(methodNames(myasthurts.MethodSet(byPointer))).To(ConsistOf("String", "Set"))
+		})
+
+		It("should check implementations for values and pointers", func() {
+			setter, ok := pkg.InterfaceByName("Setter")
+			Expect(ok).To(BeTrue())
+			stringer, ok := pkg.InterfaceByName("Stringer")
+			Expect(ok).To(BeTrue())
+
+			value, ok := pkg.StructByName("Value")
+			Expect(ok).To(BeTrue())
+			Expect(value.Implements(stringer)).To(BeTrue())
+			Expect(value.Implements(setter)).To(BeFalse())
+			Expect(value.ImplementsPtr(stringer)).To(BeTrue())
+			Expect(value.ImplementsPtr(setter)).To(BeTrue())
+
+			byPointer, ok := pkg.StructByName("ByPointer")
+			Expect(ok).To(BeTrue())
+			Expect(byPointer.Implements(setter)).To(BeTrue())
+
+			ref, ok := pkg.RefTypeByName("ByValue")
+			Expect(ok).To(BeTrue())
+			Expect(myasthurts.Implements(ref, setter)).To(BeFalse())
+			Expect(myasthurts.Implements(myasthurts.NewStarRefType(ref), setter)).To(BeTrue())
+		})
+	})
+
 	Describe("Compatible", func() {
 		It("should find methods compatible", func() {
 			ref1 := myasthurts.NewRefType("ref1", nil, nil)
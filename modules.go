@@ -0,0 +1,311 @@
+package myasthurts
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// ModuleVersion is a module path with its version. Ex:
+// `github.com/pkg/errors v0.9.1`.
+type ModuleVersion struct {
+	Path    string
+	Version string
+}
+
+// ModuleReplace is a replace directive of a go.mod file. For replacements by
+// local directories, `New.Version` is empty and `New.Path` is the directory.
+type ModuleReplace struct {
+	Old ModuleVersion
+	New ModuleVersion
+}
+
+// Module is a Go module, read from its go.mod file.
+type Module struct {
+	Path string
+	// Dir is the directory where the go.mod file is.
+	Dir       string
+	GoVersion string
+	Require   []ModuleVersion
+	Replace   []ModuleReplace
+}
+
+// ReadModule reads the go.mod file of the given directory.
+func ReadModule(dir string) (*Module, error) {
+	fileName := filepath.Join(dir, "go.mod")
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	f, err := modfile.Parse(fileName, data, nil)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidModFile, err.Error())
+	}
+	if f.Module == nil {
+		return nil, errors.Wrapf(ErrInvalidModFile, "%s: module directive not found", fileName)
+	}
+
+	m := &Module{
+		Path:    f.Module.Mod.Path,
+		Dir:     dir,
+		Require: make([]ModuleVersion, 0, len(f.Require)),
+		Replace: make([]ModuleReplace, 0, len(f.Replace)),
+	}
+	if f.Go != nil {
+		m.GoVersion = f.Go.Version
+	}
+	for _, r := range f.Require {
+		m.Require = append(m.Require, ModuleVersion{Path: r.Mod.Path, Version: r.Mod.Version})
+	}
+	for _, r := range f.Replace {
+		m.Replace = append(m.Replace, ModuleReplace{
+			Old: ModuleVersion{Path: r.Old.Path, Version: r.Old.Version},
+			New: ModuleVersion{Path: r.New.Path, Version: r.New.Version},
+		})
+	}
+	// Other directives (Ex: exclude, retract) do not affect how import paths
+	// are resolved.
+	return m, nil
+}
+
+// FindModule looks for the go.mod file in the given directory and its
+// parents. It returns ErrModuleNotFound if there is no go.mod.
+func FindModule(dir string) (*Module, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if fileExists(filepath.Join(dir, "go.mod")) {
+			return ReadModule(dir)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, ErrModuleNotFound
+		}
+		dir = parent
+	}
+}
+
+// moduleDir returns the directory of the package with the given import path,
+// as resolved by the module. As the go command does, the package is provided
+// by the module with the longest path that is a prefix of the import path,
+// considering the main module and the modules required or replaced. With a
+// vendor directory, the packages of the other modules are taken from it. No
+// module is downloaded.
+func (m *Module) moduleDir(importPath, modCache string) (string, bool) {
+	vendored := fileExists(filepath.Join(m.Dir, "vendor", "modules.txt"))
+	for _, modulePath := range m.modulePaths(importPath) {
+		rest, _ := importPathRest(modulePath, importPath)
+		if modulePath == m.Path {
+			if dir, ok := localPackageDir(m.Dir, rest); ok {
+				return dir, true
+			}
+			continue
+		}
+		if vendored {
+			if dir, ok := dirIfExists(filepath.Join(m.Dir, "vendor", filepath.FromSlash(importPath))); ok {
+				return dir, true
+			}
+			continue
+		}
+		if replace, ok := m.replacement(modulePath); ok {
+			if replace.New.Version == "" { // Local directory
+				dir := replace.New.Path
+				if !filepath.IsAbs(dir) {
+					dir = filepath.Join(m.Dir, dir)
+				}
+				if dir, ok := localPackageDir(dir, rest); ok {
+					return dir, true
+				}
+			} else if dir, ok := moduleCacheDir(modCache, replace.New, rest); ok {
+				return dir, true
+			}
+			continue
+		}
+		if version := m.requiredVersion(modulePath); version != "" {
+			if dir, ok := moduleCacheDir(modCache, ModuleVersion{Path: modulePath, Version: version}, rest); ok {
+				return dir, true
+			}
+		}
+	}
+
+	// Dependencies of dependencies may not be listed by older go.mod files.
+	// The latest version in the module cache is used for them.
+	return latestModuleCacheDir(modCache, importPath)
+}
+
+// modulePaths returns the paths of the main module and of the modules
+// required or replaced that are prefixes of the import path, the longest
+// first.
+func (m *Module) modulePaths(importPath string) []string {
+	paths := make([]string, 0, 2)
+	seen := make(map[string]bool)
+	add := func(modulePath string) {
+		if _, ok := importPathRest(modulePath, importPath); ok && !seen[modulePath] {
+			seen[modulePath] = true
+			paths = append(paths, modulePath)
+		}
+	}
+	add(m.Path)
+	for _, r := range m.Replace {
+		add(r.Old.Path)
+	}
+	for _, r := range m.Require {
+		add(r.Path)
+	}
+	sort.SliceStable(paths, func(i, j int) bool {
+		return len(paths[i]) > len(paths[j])
+	})
+	return paths
+}
+
+// replacement returns the replace directive of the module. Replacements of
+// the required version have precedence over the ones of all versions.
+func (m *Module) replacement(modulePath string) (ModuleReplace, bool) {
+	var (
+		replace ModuleReplace
+		found   bool
+	)
+	for _, r := range m.Replace {
+		if r.Old.Path != modulePath {
+			continue
+		}
+		if r.Old.Version == "" {
+			replace, found = r, true
+		} else if r.Old.Version == m.requiredVersion(modulePath) {
+			return r, true
+		}
+	}
+	return replace, found
+}
+
+// requiredVersion returns the version of a required module.
+func (m *Module) requiredVersion(modulePath string) string {
+	for _, r := range m.Require {
+		if r.Path == modulePath {
+			return r.Version
+		}
+	}
+	return ""
+}
+
+// importPathFor returns the import path of the package in the given
+// directory, if it belongs to the module.
+func (m *Module) importPathFor(dir string) (string, bool) {
+	rel, err := filepath.Rel(m.Dir, dir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	if rel == "." {
+		return m.Path, true
+	}
+	return m.Path + "/" + filepath.ToSlash(rel), true
+}
+
+// importPathRest returns the path of the package inside of the module, if
+// the import path belongs to the module. Ex: `b/c` for `a/b/c` in module `a`.
+func importPathRest(modulePath, importPath string) (string, bool) {
+	if importPath == modulePath {
+		return "", true
+	}
+	if strings.HasPrefix(importPath, modulePath+"/") {
+		return filepath.FromSlash(importPath[len(modulePath)+1:]), true
+	}
+	return "", false
+}
+
+// localPackageDir returns the directory of a package of a module stored in a
+// local directory. Directories of nested modules, with their own go.mod, do
+// not belong to the module.
+func localPackageDir(moduleDir, rest string) (string, bool) {
+	dir, ok := dirIfExists(filepath.Join(moduleDir, rest))
+	if !ok {
+		return "", false
+	}
+	root := filepath.Clean(moduleDir)
+	for d := dir; d != root && d != filepath.Dir(d); d = filepath.Dir(d) {
+		if fileExists(filepath.Join(d, "go.mod")) {
+			return "", false
+		}
+	}
+	return dir, true
+}
+
+// moduleCacheDir returns the directory of a package inside of a module
+// stored in the module cache.
+func moduleCacheDir(modCache string, mod ModuleVersion, rest string) (string, bool) {
+	if modCache == "" {
+		return "", false
+	}
+	escapedPath, err := module.EscapePath(mod.Path)
+	if err != nil {
+		return "", false
+	}
+	escapedVersion, err := module.EscapeVersion(mod.Version)
+	if err != nil {
+		return "", false
+	}
+	return dirIfExists(filepath.Join(modCache, escapedPath+"@"+escapedVersion, rest))
+}
+
+// latestModuleCacheDir looks for any version of the module of the import path
+// in the module cache, preferring the latest one.
+func latestModuleCacheDir(modCache, importPath string) (string, bool) {
+	if modCache == "" {
+		return "", false
+	}
+	// The module path is a prefix of the import path. The longest one is
+	// tried first.
+	elems := strings.Split(importPath, "/")
+	for i := len(elems); i > 0; i-- {
+		escapedPath, err := module.EscapePath(strings.Join(elems[:i], "/"))
+		if err != nil {
+			continue
+		}
+		versions, _ := filepath.Glob(filepath.Join(modCache, escapedPath+"@*"))
+		if len(versions) == 0 {
+			continue
+		}
+		sort.Slice(versions, func(a, b int) bool {
+			return semver.Compare(cachedVersion(versions[a]), cachedVersion(versions[b])) < 0
+		})
+		rest := filepath.FromSlash(strings.Join(elems[i:], "/"))
+		for j := len(versions) - 1; j >= 0; j-- {
+			if dir, ok := dirIfExists(filepath.Join(versions[j], rest)); ok {
+				return dir, true
+			}
+		}
+	}
+	return "", false
+}
+
+// cachedVersion returns the version of a module directory of the module
+// cache. Ex: `v1.0.0` for `example.com/mod@v1.0.0`.
+func cachedVersion(dir string) string {
+	base := filepath.Base(dir)
+	version, err := module.UnescapeVersion(base[strings.LastIndex(base, "@")+1:])
+	if err != nil {
+		return ""
+	}
+	return version
+}
+
+func fileExists(p string) bool {
+	info, err := os.Stat(p)
+	return err == nil && !info.IsDir()
+}
+
+func dirIfExists(p string) (string, bool) {
+	info, err := os.Stat(p)
+	if err != nil || !info.IsDir() {
+		return "", false
+	}
+	return p, true
+}
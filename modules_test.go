@@ -0,0 +1,180 @@
+package myasthurts_test
+
+import (
+	"go/constant"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	myasthurts "github.com/jamillosantos/go-my-ast-hurts"
+)
+
+var _ = Describe("Modules", func() {
+	Describe("ReadModule", func() {
+		It("should read the go.mod file", func() {
+			module, err := myasthurts.ReadModule("data/modules/app")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(module.Path).To(Equal("example.com/app"))
+			Expect(module.Dir).To(Equal("data/modules/app"))
+			Expect(module.GoVersion).To(Equal("1.21"))
+			Expect(module.Require).To(Equal([]myasthurts.ModuleVersion{
+				{Path: "example.com/Upper", Version: "v1.0.0"},
+				{Path: "example.com/app/plugins", Version: "v1.0.0"},
+				{Path: "example.com/lib", Version: "v1.2.0"},
+				{Path: "github.com/pkg/errors", Version: "v0.9.1"},
+			}))
+			Expect(module.Replace).To(Equal([]myasthurts.ModuleReplace{
+				{
+					Old: myasthurts.ModuleVersion{Path: "example.com/lib"},
+					New: myasthurts.ModuleVersion{Path: "../lib"},
+				},
+				{
+					Old: myasthurts.ModuleVersion{Path: "example.com/old", Version: "v0.1.0"},
+					New: myasthurts.ModuleVersion{Path: "example.com/new", Version: "v0.2.0"},
+				},
+			}))
+		})
+
+		It("should fail reading a directory without go.mod", func() {
+			_, err := myasthurts.ReadModule("data/modules/lib")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("FindModule", func() {
+		It("should find the module in the parent directories", func() {
+			module, err := myasthurts.FindModule("data/modules/app/internal/models")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(module.Path).To(Equal("example.com/app"))
+
+			abs, err := filepath.Abs("data/modules/app")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(module.Dir).To(Equal(abs))
+		})
+
+		It("should fail when there is no module", func() {
+			_, err := myasthurts.FindModule(string(filepath.Separator))
+			Expect(err).To(Equal(myasthurts.ErrModuleNotFound))
+		})
+	})
+
+	Describe("Environment", func() {
+		var env *myasthurts.Environment
+
+		BeforeEach(func() {
+			var err error
+			env, err = myasthurts.NewEnvironment()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should use the module of the current directory", func() {
+			module, err := env.Module()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(module.Path).To(Equal("github.com/jamillosantos/go-my-ast-hurts"))
+
+			env.Config.CurrentDir = "data/modules/app/internal"
+			module, err = env.Module()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(module.Path).To(Equal("example.com/app"))
+		})
+
+		It("should resolve the packages of the module and of replaced modules", func() {
+			env.Config.CurrentDir = "data/modules/app"
+
+			pkg, err := env.Parse("example.com/app/internal/models")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pkg.ImportPath).To(Equal("example.com/app/internal/models"))
+			Expect(pkg.Structs).To(HaveLen(1))
+
+			typesPkg, err := env.Parse("example.com/lib/types")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(typesPkg.NamedTypes).To(HaveLen(1))
+			Expect(pkg.Structs[0].Fields[0].RefType.Pkg()).To(Equal(typesPkg))
+		})
+
+		It("should resolve the packages from the module cache", func() {
+			env.Config.CurrentDir = "data/modules/app"
+			env.Config.ModCache = "data/modules/testdata/cache"
+
+			buildPkg, err := env.Import("github.com/pkg/errors")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buildPkg.ImportPath).To(Equal("github.com/pkg/errors"))
+			Expect(buildPkg.Dir).To(HaveSuffix(filepath.Join("github.com", "pkg", "errors@v0.9.1")))
+		})
+
+		It("should escape the module paths of the module cache", func() {
+			env.Config.CurrentDir = "data/modules/app"
+			env.Config.ModCache = "data/modules/testdata/cache"
+
+			pkg, err := env.Parse("example.com/Upper/text")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pkg.NamedTypes).To(HaveLen(1))
+			Expect(pkg.NamedTypes[0].Name()).To(Equal("Text"))
+		})
+
+		It("should prefer the latest version of modules not required", func() {
+			env.Config.CurrentDir = "data/modules/app"
+			env.Config.ModCache = "data/modules/testdata/cache"
+
+			pkg, err := env.Parse("example.com/versions/release")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pkg.RealPath).To(ContainSubstring("versions@v1.10.0" + string(filepath.Separator)))
+			version, ok := pkg.ConstantByName("Version")
+			Expect(ok).To(BeTrue())
+			Expect(constant.StringVal(version.Value)).To(Equal("v1.10.0"))
+		})
+
+		It("should resolve the packages of nested modules by the longest module path", func() {
+			env.Config.CurrentDir = "data/modules/app"
+			env.Config.ModCache = "data/modules/testdata/cache"
+
+			pkg, err := env.Parse("example.com/app/plugins/auth")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pkg.RealPath).To(ContainSubstring("plugins@v1.0.0" + string(filepath.Separator)))
+			Expect(pkg.NamedTypes).To(HaveLen(1))
+			Expect(pkg.NamedTypes[0].Name()).To(Equal("Token"))
+		})
+
+		It("should not resolve the packages of nested modules from the main module", func() {
+			env.Config.CurrentDir = "data/modules/app"
+			env.Config.ModCache = GinkgoT().TempDir()
+
+			_, err := env.Import("example.com/app/plugins/auth")
+			Expect(err).To(MatchError(ContainSubstring("cannot find package")))
+		})
+
+		It("should resolve the packages from the vendor directory", func() {
+			env.Config.CurrentDir = "data/modules/vendored"
+
+			pkg, err := env.Parse("example.com/dep")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pkg.Structs).To(HaveLen(1))
+			Expect(pkg.RealPath).To(HaveSuffix(filepath.Join("vendor", "example.com", "dep")))
+		})
+
+		It("should set the import path of directories of the module", func() {
+			pkg, err := env.ParseDir("./data/parse_dir")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pkg.ImportPath).To(Equal("github.com/jamillosantos/go-my-ast-hurts/data/parse_dir"))
+		})
+
+		It("should fail resolving packages not found", func() {
+			env.Config.CurrentDir = "data/modules/app"
+			env.Config.ModCache = GinkgoT().TempDir()
+
+			_, err := env.Import("example.com/Upper/text")
+			Expect(err).To(MatchError(ContainSubstring("cannot find package")))
+		})
+
+		It("should resolve packages of the standard library", func() {
+			env.Config.CurrentDir = "data/modules/app"
+
+			buildPkg, err := env.Import("net/http")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buildPkg.Goroot).To(BeTrue())
+			Expect(buildPkg.ImportPath).To(Equal("net/http"))
+		})
+	})
+})
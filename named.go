@@ -0,0 +1,21 @@
+package myasthurts
+
+// NamedType represents a type declaration that is neither a struct nor an
+// interface. Ex: `type Status int`, `type Handler func()`, `type IDs []string`.
+type NamedType struct {
+	BaseType
+	Doc        Doc
+	TypeParams []*TypeParam
+	// Underlying is the type used on the declaration.
+	Underlying RefType
+	Position   Position
+	// Platforms where the type exists, see Platform.
+	Platforms []Platform
+}
+
+// NewNamedType creates a new NamedType with no underlying type defined.
+func NewNamedType(pkg *Package, name string) *NamedType {
+	return &NamedType{
+		BaseType: *NewBaseType(pkg, name),
+	}
+}
@@ -0,0 +1,95 @@
+package myasthurts_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	myasthurts "github.com/jamillosantos/go-my-ast-hurts"
+)
+
+var _ = Describe("NamedType", func() {
+	Describe("Parse", func() {
+		It("should parse named types", func() {
+			env, err := myasthurts.NewEnvironment()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(env.ParseFile(newDataPackageContext(env), "data/models17.sample.go")).To(Succeed())
+
+			pkg, ok := env.PackageByImportPath("data")
+			Expect(ok).To(BeTrue())
+
+			Expect(pkg.NamedTypes).To(HaveLen(5))
+			Expect(pkg.NamedTypes[0].Name()).To(Equal("Status"))
+			Expect(pkg.NamedTypes[0].Doc.Comments).To(HaveLen(1))
+			Expect(pkg.NamedTypes[0].Underlying.Name()).To(Equal("int"))
+			Expect(pkg.Types).To(ContainElement(pkg.NamedTypes[0]))
+
+			handler, ok := pkg.NamedTypeByName("Handler")
+			Expect(ok).To(BeTrue())
+			var md *myasthurts.MethodDescriptor
+			Expect(handler.Underlying.Type()).To(BeAssignableToTypeOf(md))
+			md = handler.Underlying.Type().(*myasthurts.MethodDescriptor)
+			Expect(md.Arguments).To(HaveLen(1))
+			Expect(md.Result).To(HaveLen(1))
+
+			ids, ok := pkg.NamedTypeByName("IDs")
+			Expect(ok).To(BeTrue())
+			var sliceRefType *myasthurts.SliceRefType
+			Expect(ids.Underlying).To(BeAssignableToTypeOf(sliceRefType))
+			Expect(ids.Underlying.Name()).To(Equal("string"))
+
+			timestamp, ok := pkg.NamedTypeByName("Timestamp")
+			Expect(ok).To(BeTrue())
+			Expect(timestamp.Underlying.Name()).To(Equal("Time"))
+			Expect(timestamp.Underlying.Pkg().ImportPath).To(Equal("time"))
+
+			admin, ok := pkg.NamedTypeByName("Admin")
+			Expect(ok).To(BeTrue())
+			user, ok := pkg.StructByName("User")
+			Expect(ok).To(BeTrue())
+			Expect(admin.Underlying.Type()).To(Equal(user))
+		})
+
+		It("should attach methods to named types", func() {
+			env, err := myasthurts.NewEnvironment()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(env.ParseFile(newDataPackageContext(env), "data/models17.sample.go")).To(Succeed())
+
+			pkg, ok := env.PackageByImportPath("data")
+			Expect(ok).To(BeTrue())
+
+			status, ok := pkg.NamedTypeByName("Status")
+			Expect(ok).To(BeTrue())
+			Expect(status.Methods()).To(HaveLen(2))
+			Expect(status.MethodsMap()).To(HaveKey("Valid"))
+			Expect(status.MethodsMap()).To(HaveKey("String"))
+
+			ref, ok := pkg.RefTypeByName("Status")
+			Expect(ok).To(BeTrue())
+			Expect(ref.Type()).To(Equal(status))
+
+			user, ok := pkg.StructByName("User")
+			Expect(ok).To(BeTrue())
+			Expect(user.Fields[1].RefType).To(Equal(ref))
+		})
+
+		It("should resolve interfaces referenced before their declaration", func() {
+			env, err := myasthurts.NewEnvironment()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(env.ParseFile(newDataPackageContext(env), "data/models17.sample.go")).To(Succeed())
+
+			pkg, ok := env.PackageByImportPath("data")
+			Expect(ok).To(BeTrue())
+
+			named, ok := pkg.InterfaceByName("Named")
+			Expect(ok).To(BeTrue())
+			Expect(named.Methods()).To(HaveLen(1))
+
+			profile, ok := pkg.StructByName("Profile")
+			Expect(ok).To(BeTrue())
+			Expect(profile.Fields[0].RefType.Type()).To(Equal(named))
+		})
+	})
+})
@@ -3,6 +3,9 @@ package myasthurts
 import (
 	"fmt"
 	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
 
 	"github.com/fatih/structtag"
 	"github.com/pkg/errors"
@@ -30,6 +33,28 @@ func parseFileName(ctx *ParseFileContext) error {
 	return nil
 }
 
+// nodePosition returns the Position of the node in the file being parsed.
+func nodePosition(ctx *ParseFileContext, node ast.Node) Position {
+	return Position{
+		Start: ctx.FSet.Position(node.Pos()),
+		End:   ctx.FSet.Position(node.End()),
+	}
+}
+
+// namePosition returns the Position of the i-th name declared by the node.
+// It starts at the name and ends at the end of the node, whether the node
+// declares one or many names (Ex: `X, Y float64`). Nodes without names (Ex:
+// embedded fields) get the Position of the whole node.
+func namePosition(ctx *ParseFileContext, node ast.Node, names []*ast.Ident, i int) Position {
+	if i >= len(names) {
+		return nodePosition(ctx, node)
+	}
+	return Position{
+		Start: ctx.FSet.Position(names[i].Pos()),
+		End:   ctx.FSet.Position(node.End()),
+	}
+}
+
 func parseComments(doc *ast.CommentGroup) (r []string, exrr error) {
 	sizeList := len(doc.List)
 
@@ -52,6 +77,10 @@ func parseGenDecl(ctx *ParseFileContext, s *ast.GenDecl) error {
 		}
 	}
 
+	if s.Tok == token.CONST {
+		return parseConstDecl(ctx, s, docs)
+	}
+
 	for _, spec := range s.Specs {
 		err = parseSpec(ctx, spec, docs)
 		if err != nil {
@@ -61,16 +90,92 @@ func parseGenDecl(ctx *ParseFileContext, s *ast.GenDecl) error {
 	return nil
 }
 
+// parseConstDecl parses the constants of a const declaration. Inside const
+// blocks, a spec without type and values repeats the previous ones and iota is
+// the index of the spec in the block.
+func parseConstDecl(ctx *ParseFileContext, decl *ast.GenDecl, docComments []string) error {
+	var (
+		typeExpr ast.Expr
+		values   []ast.Expr
+	)
+	for iota, spec := range decl.Specs {
+		valueSpec, ok := spec.(*ast.ValueSpec)
+		if !ok {
+			continue
+		}
+		if valueSpec.Type != nil || len(valueSpec.Values) > 0 {
+			typeExpr, values = valueSpec.Type, valueSpec.Values
+		}
+
+		doc := Doc{}
+		if valueSpec.Doc != nil {
+			comments, err := parseComments(valueSpec.Doc)
+			if err != nil {
+				return err
+			}
+			doc.Comments = comments
+		} else if !decl.Lparen.IsValid() {
+			// Not a block, the documentation belongs to the declaration.
+			doc.Comments = docComments
+		}
+
+		var refType RefType = NullRefType
+		if typeExpr != nil {
+			var err error
+			if refType, err = parseType(ctx, typeExpr); err != nil {
+				return err
+			}
+		}
+
+		for i, name := range valueSpec.Names {
+			if name.Name == "_" { // Blank constants are not declared.
+				continue
+			}
+			c := &Constant{
+				Name:     name.Name,
+				RefType:  refType,
+				Doc:      doc,
+				Value:    constant.MakeUnknown(),
+				Iota:     iota,
+				Position: namePosition(ctx, valueSpec, valueSpec.Names, i),
+				ctx:      ctx,
+				typed:    typeExpr != nil,
+			}
+			if i < len(values) {
+				c.expr = values[i]
+				c.Expr = types.ExprString(values[i])
+			}
+			if !ctx.typesConstant(c, name) {
+				c.eval()
+			}
+			ctx.Package.AppendConstant(c)
+			ctx.file.AppendConstant(c)
+		}
+	}
+	return nil
+}
+
 func parseInterface(ctx *ParseFileContext, name string, spec *ast.InterfaceType, docComments []string) (*Interface, error) {
 	i := NewInterface(ctx.Package, name)
+	i.Position = nodePosition(ctx, spec)
 	for _, m := range spec.Methods.List {
 		switch t := m.Type.(type) {
-		case *ast.Ident:
-			// This case is for composing interfaces for this same package.
-			// TODO(jota): Parse interfaces and create a mechanism for "including methods".
-		case *ast.SelectorExpr:
-			// This case is for composing interfaces from other packages.
-			// TODO(jota): Parse interfaces and create a mechanism for "including methods".
+		case *ast.Ident, *ast.SelectorExpr, *ast.IndexExpr, *ast.IndexListExpr:
+			// This case is for composing interfaces. Methods of the embedded
+			// interfaces are included by `Interface.Methods`. Interfaces from
+			// other packages are resolved on demand (see EnvConfig.Resolve).
+			refType, err := parseType(ctx, t)
+			if err != nil {
+				return nil, err
+			}
+			i.addEmbedded(refType)
+		case *ast.BinaryExpr, *ast.UnaryExpr:
+			// This case is for type elements of constraints. Ex: ~int | ~string
+			refType, err := parseType(ctx, t)
+			if err != nil {
+				return nil, err
+			}
+			i.Unions = append(i.Unions, typeLocked(refType).(*Union))
 		case *ast.FuncType:
 			name := ""
 			if len(m.Names) > 0 {
@@ -80,6 +185,7 @@ func parseInterface(ctx *ParseFileContext, name string, spec *ast.InterfaceType,
 			if err != nil {
 				return nil, err
 			}
+			md.Position = nodePosition(ctx, m)
 			i.AddMethod(&TypeMethod{
 				Name:       md.Name(),
 				Descriptor: md,
@@ -89,90 +195,74 @@ func parseInterface(ctx *ParseFileContext, name string, spec *ast.InterfaceType,
 			return nil, errors.Wrapf(ErrUnexpectedExpressionType, "%T found while parsing %s (%s)", m.Type, name, pos.String())
 		}
 	}
+	if i.pendingEmbeds() {
+		// Types declared later are classified once the file is parsed, see
+		// resolveEmbeds.
+		ctx.Package.interfaces = append(ctx.Package.interfaces, i)
+	}
 	return i, nil
 }
 
 func parseSpec(ctx *ParseFileContext, spec ast.Spec, docComments []string) error {
 	switch s := spec.(type) {
 	case *ast.TypeSpec:
-		nameType := s.Name.Name
-		switch t := s.Type.(type) {
-		case *ast.InterfaceType:
-			i, err := parseInterface(ctx, nameType, t, docComments)
-			if err != nil {
-				return err
-			}
-			ctx.Package.AppendInterface(i)
-		case *ast.StructType:
-			declStruct := NewStruct(ctx.Package, nameType)
-			declStruct.Doc = Doc{
-				Comments: docComments,
-			}
-
-			// Get the refType from the package.
-			refType, ok := ctx.Package.RefTypeByName(nameType)
-			if ok {
-				// If the refType exists...
-				if refType.Type() != nil { // if the refType is already resolved
-					bt, ok := refType.Type().(*BaseType)
-					if !ok { // That means a double declaration or some unexpected error...
-						return fmt.Errorf("type %t was not expected", refType.Type())
-					}
-					// Since it is a baseType, we should make it specific
-					// (struct) and use its already defined methods ...
-					declStruct.BaseType = *bt
-				}
-				refType.AppendType(declStruct) // Realizes the refType
-			} else {
-				// If the ref type does not exists, creates and registers it.
-				refType = NewRefType(nameType, ctx.Package, declStruct)
-				ctx.Package.AddRefType(refType)
-			}
-
-			err := parseStruct(ctx, t, declStruct)
-			if err != nil {
-				return err
-			}
-			ctx.Package.AppendStruct(declStruct)
-		case *ast.Ident:
-			if ctx.File.Name.Name == "builtin" {
-				if nameType != t.Name {
-					ctx.Package.AppendRefType(nameType)
-				} else {
-					ctx.Package.AppendRefType(t.Name)
-				}
-			}
-		}
+		return parseTypeSpec(ctx, s, docComments)
 
 	case *ast.ImportSpec:
 		importPathPkg := s.Path.Value[1 : len(s.Path.Value)-1]
 
 		// Tries to find the package on the list...
-		pkg, pkgExists := ctx.Env.PackageByImportPath(importPathPkg)
+		pkg, pkgExists := ctx.Env.packageLocked(importPathPkg)
 
-		buildPackage, err := ctx.Env.Import(importPathPkg)
+		buildPackage, err := ctx.Env.importForPlatforms(importPathPkg, ctx.file.Platforms)
 		if err != nil {
 			return err
 		}
 
 		if !pkgExists {
 			pkg = NewPackage(buildPackage)
+			pkg.depth = ctx.Package.depth + 1
 			ctx.Env.AppendPackage(pkg)
+		} else if pkg.depth > ctx.Package.depth+1 {
+			pkg.depth = ctx.Package.depth + 1
+		}
+
+		imp := &Import{
+			Path:     importPathPkg,
+			Position: nodePosition(ctx, s),
+			Package:  pkg,
+		}
+		if s.Doc != nil {
+			imp.Doc.Comments, err = parseComments(s.Doc)
+			if err != nil {
+				return err
+			}
 		}
+		ctx.file.AppendImport(imp)
 
 		if s.Name != nil { // The name is the identifier of the import. Ex: t "time", t would be the name
+			imp.Alias = s.Name.Name
+			switch s.Name.Name {
 			// This checks if the import is a dot import. That means we have
 			// to include this imported package into a special list for
 			// prior querying. Dot imports include all types declared into
 			// the same contexts for this file. So, types don't have the
 			// package identification.
-			if s.Name.Name == "." {
-				pkgCtx := NewPackageContext(pkg, buildPackage)
-				if err = ctx.Env.parsePackage(pkgCtx); err != nil {
-					return err
+			case ".":
+				imp.Dot = true
+				// Packages already parsed, or being parsed (Ex: by another file
+				// of the same package), are not parsed again.
+				if !pkg.Explored && !pkg.isLoading() {
+					if err = ctx.Env.parsePackage(ctx.Env.packageContext(pkg, buildPackage)); err != nil {
+						return err
+					}
 				}
 				ctx.dotImports = append(ctx.dotImports, pkg) // If we do explore, it means the package is dot imported.
-			} else {
+			// Blank imports are only for side effects, the package cannot be
+			// referred by the file.
+			case "_":
+				imp.Blank = true
+			default:
 				// Sets the alias of the package for this file context.
 				ctx.packageImportAliasMap[s.Name.Name] = pkg
 			}
@@ -181,15 +271,238 @@ func parseSpec(ctx *ParseFileContext, spec ast.Spec, docComments []string) error
 			ctx.packageImportAliasMap[buildPackage.Name] = pkg
 		}
 	case *ast.ValueSpec:
-		variable, err := parseVariable(ctx, s)
+		variables, err := parseVariable(ctx, s)
 		if err != nil {
 			return err
 		}
-		ctx.Package.AppendVariable(variable)
+		for _, variable := range variables {
+			ctx.Package.AppendVariable(variable)
+			ctx.file.AppendVariable(variable)
+		}
 	}
 	return nil
 }
 
+func parseTypeSpec(ctx *ParseFileContext, s *ast.TypeSpec, docComments []string) error {
+	nameType := s.Name.Name
+
+	typeParams, restoreScope, err := parseTypeParams(ctx, s.TypeParams)
+	if err != nil {
+		return err
+	}
+	defer restoreScope()
+
+	// Aliases do not declare new types, they just refer to the aliased one.
+	// Ex: `type Time = time.Time`
+	if s.Assign.IsValid() {
+		alias := NewAlias(ctx.Package, nameType)
+		alias.Doc = Doc{
+			Comments: docComments,
+		}
+		alias.Position = nodePosition(ctx, s)
+
+		// The target is parsed first so methods already added to the alias are
+		// moved to the aliased type.
+		if alias.Target, err = parseType(ctx, s.Type); err != nil {
+			return err
+		}
+		if err = declareType(ctx, nameType, alias); err != nil {
+			return err
+		}
+		ctx.Package.AppendAlias(alias)
+		ctx.file.AppendAlias(alias)
+		return nil
+	}
+
+	// Predeclared types are only identifiers. Ex: `type int int`
+	if ident, ok := s.Type.(*ast.Ident); ok && ctx.File.Name.Name == "builtin" {
+		if nameType != ident.Name {
+			ctx.Package.AppendRefType(nameType)
+		} else {
+			ctx.Package.AppendRefType(ident.Name)
+		}
+		return nil
+	}
+
+	switch t := s.Type.(type) {
+	case *ast.InterfaceType:
+		i, err := parseInterface(ctx, nameType, t, docComments)
+		if err != nil {
+			return err
+		}
+		i.TypeParams = typeParams
+		i.Position = nodePosition(ctx, s)
+		if err = declareType(ctx, nameType, i); err != nil {
+			return err
+		}
+		ctx.Package.AppendInterface(i)
+		ctx.file.AppendInterface(i)
+	case *ast.StructType:
+		declStruct := NewStruct(ctx.Package, nameType)
+		declStruct.Doc = Doc{
+			Comments: docComments,
+		}
+		declStruct.TypeParams = typeParams
+		declStruct.Position = nodePosition(ctx, s)
+
+		// The type is declared before parsing the fields, so fields referring
+		// to the struct itself get its RefType.
+		if err = declareType(ctx, nameType, declStruct); err != nil {
+			return err
+		}
+
+		if err = parseStruct(ctx, t, declStruct); err != nil {
+			return err
+		}
+		ctx.Package.AppendStruct(declStruct)
+		ctx.file.AppendStruct(declStruct)
+	default:
+		namedType := NewNamedType(ctx.Package, nameType)
+		namedType.Doc = Doc{
+			Comments: docComments,
+		}
+		namedType.TypeParams = typeParams
+		namedType.Position = nodePosition(ctx, s)
+
+		if err = declareType(ctx, nameType, namedType); err != nil {
+			return err
+		}
+
+		underlying, err := parseType(ctx, t)
+		if err != nil {
+			return err
+		}
+		namedType.Underlying = underlying
+		ctx.Package.AppendNamedType(namedType)
+		ctx.file.AppendNamedType(namedType)
+	}
+	return nil
+}
+
+// declareType realizes the RefType for a type declared in the package. If the
+// RefType was already referenced (Ex: a method declared before the type), its
+// methods are moved to the declared type.
+func declareType(ctx *ParseFileContext, name string, t Type) error {
+	// Get the refType from the package.
+	refType, ok := ctx.Package.RefTypeByName(name)
+	if !ok {
+		// If the ref type does not exists, creates and registers it.
+		ctx.Package.AddRefType(NewRefType(name, ctx.Package, t))
+		return nil
+	}
+
+	// If the refType exists...
+	if typeLocked(refType) != nil { // if the refType is already resolved
+		bt, ok := typeLocked(refType).(*BaseType)
+		if !ok && disjointPlatforms(platformsOf(typeLocked(refType)), ctx.file.Platforms) {
+			// The type is declared for other platforms. Ex: `type stat struct`
+			// on stat_linux.go and stat_windows.go. The RefType keeps
+			// referring to the first declaration and the files of these
+			// platforms get their own RefType.
+			ctx.Package.declarePlatformType(name, ctx.file.Platforms, t)
+			return nil
+		}
+		if !ok { // That means a double declaration or some unexpected error...
+			return fmt.Errorf("type %T was not expected", typeLocked(refType))
+		}
+		// Since it is a baseType, we should make it specific and use its
+		// already defined methods ...
+		for _, method := range bt.Methods() {
+			if disjointPlatforms(method.Descriptor.Platforms, ctx.file.Platforms) {
+				ctx.Package.movePlatformMethod(name, method)
+				continue
+			}
+			bindRecvTypeParams(method.Descriptor, t)
+			t.AddMethod(method)
+		}
+	}
+	refType.AppendType(t) // Realizes the refType
+	return nil
+}
+
+// parseTypeParams parses the type parameters of a generic declaration and
+// adds them to the context scope. The returned function restores the previous
+// scope and must be called after the declaration is parsed.
+func parseTypeParams(ctx *ParseFileContext, fields *ast.FieldList) ([]*TypeParam, func(), error) {
+	if fields == nil || len(fields.List) == 0 {
+		return nil, func() {}, nil
+	}
+
+	typeParams := make([]*TypeParam, 0, len(fields.List))
+	scope := make(map[string]RefType, len(fields.List))
+	for _, field := range fields.List {
+		for _, name := range field.Names {
+			tp := NewTypeParam(ctx.Package, name.Name)
+			typeParams = append(typeParams, tp)
+			scope[name.Name] = NewRefType(name.Name, ctx.Package, tp)
+		}
+	}
+
+	// Constraints can refer to any type parameter of the list (Ex:
+	// `[K comparable, M ~map[K]string]`). So, all of them are added to the
+	// scope before parsing constraints.
+	restoreScope := ctx.scopeTypeParams(scope)
+	i := 0
+	for _, field := range fields.List {
+		constraint, err := parseType(ctx, field.Type)
+		if err != nil {
+			restoreScope()
+			return nil, nil, err
+		}
+		for range field.Names {
+			typeParams[i].Constraint = constraint
+			i++
+		}
+	}
+	return typeParams, restoreScope, nil
+}
+
+// parseRecvTypeParams adds the type parameters declared by a generic receiver
+// to the context scope. Ex: `T` in `func (s *Set[T]) Add(v T)`. Constraints
+// are taken from the receiver type declaration, when it is already known.
+func parseRecvTypeParams(ctx *ParseFileContext, recv ast.Expr) ([]*TypeParam, func(), error) {
+	if star, ok := recv.(*ast.StarExpr); ok {
+		recv = star.X
+	}
+
+	var (
+		origin  ast.Expr
+		indices []ast.Expr
+	)
+	switch t := recv.(type) {
+	case *ast.IndexExpr:
+		origin, indices = t.X, []ast.Expr{t.Index}
+	case *ast.IndexListExpr:
+		origin, indices = t.X, t.Indices
+	default: // Not a generic receiver.
+		return nil, func() {}, nil
+	}
+
+	var declared []*TypeParam
+	if ident, ok := origin.(*ast.Ident); ok {
+		if refType, ok := ctx.Package.RefTypeByName(ident.Name); ok && typeLocked(refType) != nil {
+			declared = typeParamsOf(typeLocked(refType))
+		}
+	}
+
+	typeParams := make([]*TypeParam, 0, len(indices))
+	scope := make(map[string]RefType, len(indices))
+	for i, index := range indices {
+		ident, ok := index.(*ast.Ident)
+		if !ok {
+			pos := ctx.FSet.Position(index.Pos())
+			return nil, nil, errors.Wrapf(ErrUnexpectedExpressionType, "%T found while parsing receiver (%s)", index, pos.String())
+		}
+		tp := NewTypeParam(ctx.Package, ident.Name)
+		if i < len(declared) {
+			tp.Constraint = declared[i].Constraint
+		}
+		typeParams = append(typeParams, tp)
+		scope[ident.Name] = NewRefType(ident.Name, ctx.Package, tp)
+	}
+	return typeParams, ctx.scopeTypeParams(scope), nil
+}
+
 func parseStruct(ctx *ParseFileContext, astStruct *ast.StructType, typeStruct *Struct) error {
 	for _, field := range astStruct.Fields.List {
 		refType, err := parseType(ctx, field.Type)
@@ -198,11 +511,8 @@ func parseStruct(ctx *ParseFileContext, astStruct *ast.StructType, typeStruct *S
 		}
 
 		f := &Field{
-			RefType: refType,
-			Position: Position{
-				FileName: typeStruct.pkg.ImportPath,
-				Line:     int(field.Pos()),
-			},
+			RefType:  refType,
+			Position: nodePosition(ctx, field),
 		}
 
 		if field.Doc != nil {
@@ -215,10 +525,6 @@ func parseStruct(ctx *ParseFileContext, astStruct *ast.StructType, typeStruct *S
 			}
 		}
 
-		if len(field.Names) > 0 {
-			f.Name = field.Names[0].Name
-		}
-
 		if field.Tag != nil && field.Tag.Value != "" {
 			f.Tag.Raw = field.Tag.Value[1 : len(field.Tag.Value)-1]
 
@@ -244,35 +550,83 @@ func parseStruct(ctx *ParseFileContext, astStruct *ast.StructType, typeStruct *S
 			}
 		}
 
-		typeStruct.Fields = append(typeStruct.Fields, f)
+		// Embedded fields are implicitly named after their type.
+		if len(field.Names) == 0 {
+			f.Name = refType.Name()
+			f.Embedded = true
+			typeStruct.Fields = append(typeStruct.Fields, f)
+			continue
+		}
+
+		// Fields declared together (Ex: `X, Y float64`) share type, tag and
+		// documentation.
+		for i, name := range fieldNames(field) {
+			nameField := *f
+			nameField.Name = name
+			nameField.Position = namePosition(ctx, field, field.Names, i)
+			typeStruct.Fields = append(typeStruct.Fields, &nameField)
+		}
 	}
 	return nil
 }
 
+// fieldNames returns the names declared by a field. Fields with no names (Ex:
+// embedded fields and unnamed arguments) result in a single empty name.
+func fieldNames(field *ast.Field) []string {
+	if len(field.Names) == 0 {
+		return []string{""}
+	}
+	names := make([]string, len(field.Names))
+	for i, name := range field.Names {
+		names[i] = name.Name
+	}
+	return names
+}
+
 func parseFuncDecl(ctx *ParseFileContext, f *ast.FuncDecl) error {
 	method := NewMethodDescriptor(ctx.Package, f.Name.Name)
+	method.Position = nodePosition(ctx, f)
+
+	typeParams, restoreScope, err := parseTypeParams(ctx, f.Type.TypeParams)
+	if err != nil {
+		return err
+	}
+	defer restoreScope()
+	method.TypeParams = typeParams
+
 	hasReceiver := f.Recv != nil && len(f.Recv.List) > 0
 	if hasReceiver {
 		field := f.Recv.List[0]
 
-		recv := MethodArgument{}
+		recvTypeParams, restoreRecvScope, err := parseRecvTypeParams(ctx, field.Type)
+		if err != nil {
+			return err
+		}
+		defer restoreRecvScope()
+		method.TypeParams = recvTypeParams
+
+		recv := MethodArgument{
+			Position: nodePosition(ctx, field),
+		}
 		refType, err := parseType(ctx, field.Type)
 		if err != nil {
 			return err
 		}
 
-		recv.Name = field.Names[0].Name
+		recv.Name = fieldNames(field)[0]
 		recv.Type = refType
 		method.Recv = append(method.Recv, recv)
+		_, method.PointerReceiver = refType.(*StarRefType)
 
 		// Add method to the type...
-		refType.Type().AddMethod(&TypeMethod{
-			Name:       field.Names[0].Name,
+		typeLocked(refType).AddMethod(&TypeMethod{
+			Name:       method.Name(),
 			Descriptor: method,
 		})
 	} else {
 		ctx.Package.AppendMethod(method)
 	}
+	ctx.file.AppendMethod(method)
 
 	// Set the method documentation.
 	if f.Doc != nil {
@@ -286,36 +640,34 @@ func parseFuncDecl(ctx *ParseFileContext, f *ast.FuncDecl) error {
 	}
 
 	for _, field := range f.Type.Params.List {
-		argument := MethodArgument{}
-		if len(field.Names) > 0 {
-			argument.Name = field.Names[0].Name
-		}
-
 		refType, err := parseType(ctx, field.Type)
 		if err != nil {
 			return err
 		}
 
-		argument.Type = refType
-
-		method.Arguments = append(method.Arguments, argument)
+		for i, name := range fieldNames(field) {
+			method.Arguments = append(method.Arguments, MethodArgument{
+				Name:     name,
+				Type:     refType,
+				Position: namePosition(ctx, field, field.Names, i),
+			})
+		}
 	}
 
 	if f.Type.Results != nil {
 		for _, field := range f.Type.Results.List {
-			r := MethodResult{}
-			if len(field.Names) > 0 {
-				r.Name = field.Names[0].Name
-			}
-
 			refType, err := parseType(ctx, field.Type)
 			if err != nil {
 				return err
 			}
 
-			r.Type = refType
-
-			method.Result = append(method.Result, r)
+			for i, name := range fieldNames(field) {
+				method.Result = append(method.Result, MethodResult{
+					Name:     name,
+					Type:     refType,
+					Position: namePosition(ctx, field, field.Names, i),
+				})
+			}
 		}
 	}
 
@@ -328,16 +680,27 @@ func parseType(ctx *ParseFileContext, t ast.Expr) (RefType, error) {
 	// This case will cover the identifier type. This is for string, int64 and
 	// types defined on the same package.
 	case *ast.Ident:
+		if rt, ok := ctx.typesRefType(ctx.typeNameOf(recvT)); ok {
+			return rt, nil
+		}
 		typeName := recvT.Name
 		rt, ok := ctx.GetRefType(typeName)
 		if !ok {
 			rt = NewRefType(typeName, ctx.Package, NewBaseType(ctx.Package, typeName))
 			ctx.Package.AddRefType(rt)
+		} else if rt.Pkg() == ctx.Package && typeLocked(rt) == nil && ctx.File.Name.Name != "builtin" {
+			// Placeholder created by other packages referring to this type
+			// (see EnsureRefType). It works as a reference declared before
+			// the type.
+			rt.AppendType(NewBaseType(ctx.Package, typeName))
 		}
 		return rt, nil
 	// This case will cover the selector type. This is for expressions like
 	// time.Time or t.Time, models.User ...
 	case *ast.SelectorExpr:
+		if rt, ok := ctx.typesRefType(ctx.typeNameOf(recvT.Sel)); ok {
+			return rt, nil
+		}
 		pkgAliasIdent, ok := recvT.X.(*ast.Ident)
 		if !ok { // We expect the recvT.X is a ast.Ident, if not...
 			return nil, errors.Wrapf(ErrUnexpectedSelector, "%T", recvT.X)
@@ -356,6 +719,7 @@ func parseType(ctx *ParseFileContext, t ast.Expr) (RefType, error) {
 		return ctx.Package.AddRefType(NewRefType("", ctx.Package, i)), nil
 	case *ast.StructType:
 		s := NewStruct(ctx.Package, "")
+		s.Position = nodePosition(ctx, recvT)
 		err := parseStruct(ctx, recvT, s)
 		if err != nil {
 			return nil, err
@@ -374,7 +738,10 @@ func parseType(ctx *ParseFileContext, t ast.Expr) (RefType, error) {
 		if err != nil {
 			return nil, err
 		}
-		return NewArrayRefType(refType), nil
+		if recvT.Len == nil {
+			return NewSliceRefType(refType), nil
+		}
+		return parseArrayLen(ctx, refType, recvT.Len)
 	case *ast.MapType:
 
 		keyRefType, err := parseType(ctx, recvT.Key)
@@ -394,7 +761,14 @@ func parseType(ctx *ParseFileContext, t ast.Expr) (RefType, error) {
 		if err != nil {
 			return nil, err
 		}
-		return NewChanRefType(refType), nil
+		dir := ChanBoth
+		switch recvT.Dir {
+		case ast.SEND:
+			dir = ChanSend
+		case ast.RECV:
+			dir = ChanRecv
+		}
+		return NewChanRefType(refType, dir), nil
 	case *ast.FuncType:
 		md, err := parseFuncType(ctx, "", recvT)
 		if err != nil {
@@ -408,15 +782,112 @@ func parseType(ctx *ParseFileContext, t ast.Expr) (RefType, error) {
 			return nil, err
 		}
 		return NewEllipsisRefType(refType), nil
+	// This case covers generic instantiations. Ex: Set[string]
+	case *ast.IndexExpr:
+		return parseInstance(ctx, recvT.X, []ast.Expr{recvT.Index})
+	// This case covers generic instantiations with many type arguments. Ex:
+	// Pair[string, int]
+	case *ast.IndexListExpr:
+		return parseInstance(ctx, recvT.X, recvT.Indices)
+	case *ast.ParenExpr:
+		return parseType(ctx, recvT.X)
+	// These cases cover type elements used on constraints. Ex: ~int | ~string
+	case *ast.BinaryExpr, *ast.UnaryExpr:
+		union := NewUnion(ctx.Package)
+		if err := parseUnion(ctx, recvT, union); err != nil {
+			return nil, err
+		}
+		return NewRefType("", ctx.Package, union), nil
 	// This is a safeguard for unexpected cases.
 	default:
 		return nil, errors.Wrapf(ErrUnexpectedExpressionType, "%T", t)
 	}
 }
 
+// parseArrayLen creates the ArrayRefType evaluating its length. Lengths that
+// depend on constants not evaluated yet are resolved after the file is
+// parsed.
+func parseArrayLen(ctx *ParseFileContext, refType RefType, lenExpr ast.Expr) (*ArrayRefType, error) {
+	array := NewArrayRefType(refType, -1)
+	array.LenExpr = types.ExprString(lenExpr)
+	// `[...]T` is only valid in composite literals, the length depends on
+	// the elements.
+	if _, ok := lenExpr.(*ast.Ellipsis); ok {
+		return array, nil
+	}
+	if l, ok := ctx.typesArrayLen(lenExpr); ok {
+		array.Len = l
+		return array, nil
+	}
+	array.ctx = ctx
+	array.lenExpr = lenExpr
+	ok, err := array.evalLen()
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		ctx.Package.arrays = append(ctx.Package.arrays, array)
+	}
+	return array, nil
+}
+
+// parseInstance returns the RefType of a generic type instantiated with the
+// given type arguments.
+func parseInstance(ctx *ParseFileContext, origin ast.Expr, args []ast.Expr) (RefType, error) {
+	refType, err := parseType(ctx, origin)
+	if err != nil {
+		return nil, err
+	}
+
+	typeArgs := make([]RefType, len(args))
+	for i, arg := range args {
+		typeArgs[i], err = parseType(ctx, arg)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return NewInstanceRefType(refType, typeArgs), nil
+}
+
+// parseUnion adds the terms of a type element expression to the given union.
+func parseUnion(ctx *ParseFileContext, expr ast.Expr, union *Union) error {
+	switch t := expr.(type) {
+	case *ast.BinaryExpr:
+		if t.Op != token.OR {
+			return errors.Wrapf(ErrUnexpectedExpressionType, "%T (%s)", t, t.Op)
+		}
+		if err := parseUnion(ctx, t.X, union); err != nil {
+			return err
+		}
+		return parseUnion(ctx, t.Y, union)
+	case *ast.UnaryExpr:
+		if t.Op != token.TILDE {
+			return errors.Wrapf(ErrUnexpectedExpressionType, "%T (%s)", t, t.Op)
+		}
+		refType, err := parseType(ctx, t.X)
+		if err != nil {
+			return err
+		}
+		union.Terms = append(union.Terms, &UnionTerm{
+			Tilde:   true,
+			RefType: refType,
+		})
+	default:
+		refType, err := parseType(ctx, t)
+		if err != nil {
+			return err
+		}
+		union.Terms = append(union.Terms, &UnionTerm{
+			RefType: refType,
+		})
+	}
+	return nil
+}
+
 func parseFuncType(ctx *ParseFileContext, name string, f *ast.FuncType) (*MethodDescriptor, error) {
 	md := &MethodDescriptor{
 		BaseType: *NewBaseType(ctx.Package, name),
+		Position: nodePosition(ctx, f),
 	}
 
 	for _, p := range f.Params.List {
@@ -425,51 +896,50 @@ func parseFuncType(ctx *ParseFileContext, name string, f *ast.FuncType) (*Method
 			return nil, err
 		}
 
-		methodArg := MethodArgument{
-			Type: refType,
-		}
-
-		if p.Names != nil {
-			methodArg.Name = p.Names[0].Name
-		}
-
+		var doc Doc
 		if p.Doc != nil {
 			docComments, err := parseComments(p.Doc)
 			if err != nil {
 				return nil, err
 			}
-			methodArg.Doc = Doc{
+			doc = Doc{
 				Comments: docComments,
 			}
 		}
 
-		md.Arguments = append(md.Arguments, methodArg)
+		for i, name := range fieldNames(p) {
+			md.Arguments = append(md.Arguments, MethodArgument{
+				Name:     name,
+				Type:     refType,
+				Doc:      doc,
+				Position: namePosition(ctx, p, p.Names, i),
+			})
+		}
 	}
 
 	if f.Results != nil {
 		for _, r := range f.Results.List {
-			name := ""
-			if len(r.Names) > 0 {
-				name = r.Names[0].Name
-			}
 			refType, err := parseType(ctx, r.Type)
 			if err != nil {
 				return nil, err
 			}
-			md.Result = append(md.Result, MethodResult{
-				Name: name,
-				Type: refType,
-			})
+			for i, name := range fieldNames(r) {
+				md.Result = append(md.Result, MethodResult{
+					Name:     name,
+					Type:     refType,
+					Position: namePosition(ctx, r, r.Names, i),
+				})
+			}
 		}
 	}
 
 	return md, nil
 }
 
-func parseVariable(ctx *ParseFileContext, vValue *ast.ValueSpec) (*Variable, error) {
-	variable := &Variable{
-		Name: vValue.Names[0].Name,
-	}
+// parseVariable returns a Variable for each name declared by the spec. Ex:
+// `var a, b int` results in two variables sharing the same RefType.
+func parseVariable(ctx *ParseFileContext, vValue *ast.ValueSpec) ([]*Variable, error) {
+	var doc Doc
 
 	// Defines the variable documentation...
 	if vValue.Doc != nil {
@@ -477,21 +947,28 @@ func parseVariable(ctx *ParseFileContext, vValue *ast.ValueSpec) (*Variable, err
 		if err != nil {
 			return nil, err
 		}
-		variable.Doc = Doc{
+		doc = Doc{
 			Comments: docComments,
 		}
 	}
 
-	if vValue.Type == nil {
-		variable.RefType = NullRefType
-	} else {
+	var refType RefType = NullRefType
+	if vValue.Type != nil {
 		// Define and set the RefType of the variable.
-		refType, err := parseType(ctx, vValue.Type)
-		if err != nil {
+		var err error
+		if refType, err = parseType(ctx, vValue.Type); err != nil {
 			return nil, err
 		}
-		variable.RefType = refType
 	}
 
-	return variable, nil
+	variables := make([]*Variable, len(vValue.Names))
+	for i, name := range vValue.Names {
+		variables[i] = &Variable{
+			Name:     name.Name,
+			RefType:  refType,
+			Doc:      doc,
+			Position: namePosition(ctx, vValue, vValue.Names, i),
+		}
+	}
+	return variables, nil
 }
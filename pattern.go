@@ -0,0 +1,197 @@
+package myasthurts
+
+import (
+	"go/build"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ParsePattern parses the packages matched by the pattern. Patterns follow
+// the `go` command: a directory (Ex: `./models`) or an import path (Ex:
+// `time`) match a single package, and the `/...` suffix matches the package
+// and all packages in its subdirectories (Ex: `./...`).
+//
+// Directories named `testdata` or `vendor`, starting with `.` or `_`, and
+// nested modules are skipped. All packages are added to the environment.
+// Then, all packages of the environment, including the ones parsed before, are
+// linked to their parents (see Package.Parent and Package.Subpackages).
+//
+// The files of the packages are parsed in parallel (see EnvConfig.Workers).
+func (env *Environment) ParsePattern(pattern string) ([]*Package, error) {
+	root, recursive := strings.TrimSuffix(pattern, "/..."), strings.HasSuffix(pattern, "/...")
+	if pattern == "..." {
+		root, recursive = ".", true
+	}
+
+	var (
+		dir string
+		err error
+	)
+	if isDirPattern(root) {
+		dir = root
+	} else if recursive {
+		if dir, err = env.importPathDir(root); err != nil {
+			return nil, err
+		} else if dir == "" {
+			return nil, errors.Wrap(ErrPackageNotFound, root)
+		}
+	} else {
+		pkg, err := env.Parse(root)
+		if err != nil {
+			return nil, err
+		}
+		return []*Package{pkg}, nil
+	}
+
+	dirs := []string{dir}
+	if recursive {
+		if dirs, err = packageDirs(dir); err != nil {
+			return nil, err
+		}
+	}
+
+	buildPkgs := make([]*build.Package, 0, len(dirs))
+	for _, d := range dirs {
+		buildPkg, err := env.ImportDir(d)
+		if _, ok := errors.Cause(err).(*build.NoGoError); ok && recursive {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		buildPkgs = append(buildPkgs, buildPkg)
+	}
+
+	packages, err := env.parseDirect(buildPkgs)
+	if err != nil {
+		return nil, err
+	}
+
+	env.parseMu.Lock()
+	defer env.parseMu.Unlock()
+	env.linkPackages()
+	return packages, nil
+}
+
+// isDirPattern checks if the pattern refers to a directory, instead of an
+// import path.
+func isDirPattern(pattern string) bool {
+	return pattern == "." || pattern == ".." || filepath.IsAbs(pattern) ||
+		strings.HasPrefix(pattern, "./") || strings.HasPrefix(pattern, "../")
+}
+
+// packageDirs lists the directory and its subdirectories that may have
+// packages, in lexical order.
+func packageDirs(root string) ([]string, error) {
+	dirs := make([]string, 0)
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if p != root {
+			name := d.Name()
+			if name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+				return filepath.SkipDir
+			}
+			if fileExists(filepath.Join(p, "go.mod")) { // Nested module
+				return filepath.SkipDir
+			}
+		}
+		dirs = append(dirs, p)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dirs, nil
+}
+
+// linkPackages sets the parent of each package of the environment as the
+// package found in the closest parent directory, and adds the package as one
+// of its subpackages. Directories without packages are skipped. Ex: `a/b/c` is
+// a subpackage of `a` if there is no package in `a/b`.
+//
+// Packages parsed before are linked again, as their closest parent may have
+// been parsed after them.
+func (env *Environment) linkPackages() {
+	env.mu.RLock()
+	packages := append([]*Package(nil), env.packages...)
+	env.mu.RUnlock()
+
+	dirs := make(map[string]*Package, len(packages))
+	pkgDirs := make(map[*Package]string, len(packages))
+	for _, pkg := range packages {
+		if pkg.RealPath == "" || pkg.ForTest != nil {
+			continue
+		}
+		if dir, err := filepath.Abs(pkg.RealPath); err == nil {
+			dirs[dir] = pkg
+			pkgDirs[pkg] = dir
+		}
+	}
+
+	for _, pkg := range packages {
+		pkgDir, ok := pkgDirs[pkg]
+		if !ok {
+			continue
+		}
+		var parent *Package
+		for dir := filepath.Dir(pkgDir); dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+			if parent, ok = dirs[dir]; ok {
+				break
+			}
+		}
+		if parent == pkg.Parent {
+			continue
+		}
+		if pkg.Parent != nil {
+			pkg.Parent.removeSubpackage(pkg)
+		}
+		pkg.Parent = parent
+		if parent != nil {
+			parent.addSubpackage(pkg, pkgDirs)
+		}
+	}
+}
+
+// addSubpackage adds the package to the subpackages, keeping them sorted by
+// directory.
+func (p *Package) addSubpackage(pkg *Package, pkgDirs map[*Package]string) {
+	i := sort.Search(len(p.Subpackages), func(i int) bool {
+		return pkgDirs[p.Subpackages[i]] > pkgDirs[pkg]
+	})
+	p.Subpackages = append(p.Subpackages, nil)
+	copy(p.Subpackages[i+1:], p.Subpackages[i:])
+	p.Subpackages[i] = pkg
+}
+
+func (p *Package) removeSubpackage(pkg *Package) {
+	for i, subpackage := range p.Subpackages {
+		if subpackage == pkg {
+			p.Subpackages = append(p.Subpackages[:i], p.Subpackages[i+1:]...)
+			return
+		}
+	}
+}
+
+// packageByDir finds the package in the absolute directory. External test
+// packages are ignored.
+func (env *Environment) packageByDir(dir string) (*Package, bool) {
+	env.mu.RLock()
+	defer env.mu.RUnlock()
+	for _, pkg := range env.packages {
+		if pkg.RealPath == "" || pkg.ForTest != nil {
+			continue
+		}
+		if pkgDir, err := filepath.Abs(pkg.RealPath); err == nil && pkgDir == dir {
+			return pkg, true
+		}
+	}
+	return nil, false
+}
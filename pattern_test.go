@@ -0,0 +1,124 @@
+package myasthurts_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	myasthurts "github.com/jamillosantos/go-my-ast-hurts"
+)
+
+var _ = Describe("ParsePattern", func() {
+	const treePath = "github.com/jamillosantos/go-my-ast-hurts/data/tree"
+
+	var env *myasthurts.Environment
+
+	BeforeEach(func() {
+		var err error
+		env, err = myasthurts.NewEnvironment()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	importPaths := func(packages []*myasthurts.Package) []string {
+		r := make([]string, len(packages))
+		for i, pkg := range packages {
+			r[i] = pkg.ImportPath
+		}
+		return r
+	}
+
+	It("should parse all packages of the tree", func() {
+		packages, err := env.ParsePattern("./data/tree/...")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(importPaths(packages)).To(Equal([]string{
+			treePath,
+			treePath + "/internal/empty/deep",
+			treePath + "/models",
+			treePath + "/models/admin",
+		}))
+
+		for _, pkg := range packages {
+			Expect(pkg.Explored).To(BeTrue())
+			found, ok := env.PackageByImportPath(pkg.ImportPath)
+			Expect(ok).To(BeTrue())
+			Expect(found).To(Equal(pkg))
+		}
+	})
+
+	It("should link the parents and the subpackages", func() {
+		packages, err := env.ParsePattern("./data/tree/...")
+		Expect(err).ToNot(HaveOccurred())
+		root, deep, models, admin := packages[0], packages[1], packages[2], packages[3]
+
+		Expect(root.Parent).To(BeNil())
+		Expect(root.Subpackages).To(Equal([]*myasthurts.Package{deep, models}))
+		Expect(deep.Parent).To(Equal(root))
+		Expect(models.Parent).To(Equal(root))
+		Expect(models.Subpackages).To(Equal([]*myasthurts.Package{admin}))
+		Expect(admin.Parent).To(Equal(models))
+		Expect(admin.Subpackages).To(BeEmpty())
+	})
+
+	It("should link the packages parsed before", func() {
+		admin, err := env.ParseDir("./data/tree/models/admin")
+		Expect(err).ToNot(HaveOccurred())
+		deep, err := env.ParseDir("./data/tree/internal/empty/deep")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(admin.Parent).To(BeNil())
+		Expect(deep.Parent).To(BeNil())
+
+		packages, err := env.ParsePattern("./data/tree")
+		Expect(err).ToNot(HaveOccurred())
+		root := packages[0]
+		// Parsed as an import of admin.
+		models, ok := env.PackageByImportPath(treePath + "/models")
+		Expect(ok).To(BeTrue())
+
+		Expect(admin.Parent).To(Equal(models))
+		Expect(models.Parent).To(Equal(root))
+		Expect(models.Subpackages).To(Equal([]*myasthurts.Package{admin}))
+		Expect(deep.Parent).To(Equal(root))
+		Expect(root.Subpackages).To(Equal([]*myasthurts.Package{deep, models}))
+	})
+
+	It("should share the packages between the imports", func() {
+		packages, err := env.ParsePattern("./data/tree/...")
+		Expect(err).ToNot(HaveOccurred())
+
+		admin, ok := packages[3].StructByName("Admin")
+		Expect(ok).To(BeTrue())
+		Expect(admin.Fields[0].RefType.Pkg()).To(Equal(packages[2]))
+		user, ok := packages[2].StructByName("User")
+		Expect(ok).To(BeTrue())
+		Expect(admin.Fields[0].RefType.Type()).To(Equal(user))
+	})
+
+	It("should parse a pattern by import path", func() {
+		packages, err := env.ParsePattern(treePath + "/models/...")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(importPaths(packages)).To(Equal([]string{treePath + "/models", treePath + "/models/admin"}))
+		Expect(packages[1].Parent).To(Equal(packages[0]))
+	})
+
+	It("should parse a single package", func() {
+		packages, err := env.ParsePattern("./data/tree/models")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(importPaths(packages)).To(Equal([]string{treePath + "/models"}))
+
+		packages, err = env.ParsePattern("strings")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(importPaths(packages)).To(Equal([]string{"strings"}))
+	})
+
+	It("should fail for packages not found", func() {
+		_, err := env.ParsePattern("example.com/unknown/...")
+		Expect(err).To(MatchError(ContainSubstring("package not found")))
+	})
+
+	It("should register the packages parsed by ParseDir", func() {
+		pkg, err := env.ParseDir("./data/tree")
+		Expect(err).ToNot(HaveOccurred())
+		found, ok := env.PackageByImportPath(treePath)
+		Expect(ok).To(BeTrue())
+		Expect(found).To(Equal(pkg))
+	})
+})
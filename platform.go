@@ -0,0 +1,221 @@
+package myasthurts
+
+import (
+	"go/build"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Platform is a combination of GOOS, GOARCH and build tags used for
+// selecting the files of packages. Ex: `linux/amd64`.
+//
+// When the packages are parsed for many platforms (see EnvConfig.Platforms),
+// files and declarations record the platforms where they exist in their
+// Platforms field. It is nil if the package was not parsed for many
+// platforms.
+type Platform struct {
+	GOOS   string
+	GOARCH string
+	Tags   []string
+}
+
+// String returns the platform as `GOOS/GOARCH`, followed by the tags, if
+// any. Ex: `linux/amd64,netgo`.
+func (p Platform) String() string {
+	s := p.GOOS + "/" + p.GOARCH
+	if len(p.Tags) > 0 {
+		s += "," + strings.Join(p.Tags, ",")
+	}
+	return s
+}
+
+// platformsOf returns the platforms where the declaration exists.
+func platformsOf(t Type) []Platform {
+	switch tt := t.(type) {
+	case *Struct:
+		return tt.Platforms
+	case *Interface:
+		return tt.Platforms
+	case *NamedType:
+		return tt.Platforms
+	case *Alias:
+		return tt.Platforms
+	}
+	return nil
+}
+
+// disjointPlatforms checks if there is no platform in both lists. Nil lists
+// mean all platforms.
+func disjointPlatforms(a, b []Platform) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	for _, pa := range a {
+		for _, pb := range b {
+			if pa.String() == pb.String() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// platformContext returns the build context of the environment for the
+// platform.
+func (env *Environment) platformContext(platform Platform) build.Context {
+	ctx := env.BuildContext
+	ctx.GOOS, ctx.GOARCH, ctx.BuildTags = platform.GOOS, platform.GOARCH, platform.Tags
+	// Cgo is only available for the platform of the build context.
+	ctx.CgoEnabled = ctx.CgoEnabled && platform.GOOS == env.BuildContext.GOOS && platform.GOARCH == env.BuildContext.GOARCH
+	return ctx
+}
+
+// importForPlatforms finds the package imported by a file built for the given
+// platforms. Packages without files for the build context of the environment
+// are found using the contexts of the platforms. Ex: `internal/syscall/windows`
+// imported by a `_windows.go` file.
+func (env *Environment) importForPlatforms(importPath string, platforms []Platform) (*build.Package, error) {
+	buildPkg, err := env.Import(importPath)
+	if _, ok := err.(*build.NoGoError); !ok {
+		return buildPkg, err
+	}
+	for _, platform := range platforms {
+		if buildPkg, platformErr := env.importContext(env.platformContext(platform), importPath); platformErr == nil {
+			return buildPkg, nil
+		}
+	}
+	return nil, err
+}
+
+// platformFiles returns the Go files of the package for any of the
+// configured platforms (see EnvConfig.Platforms), with the platforms each
+// file is built for. The keys of the map are the paths of the files.
+func (env *Environment) platformFiles(pkgCtx *ParsePackageContext) ([]string, map[string][]Platform, error) {
+	platforms := make(map[string][]Platform)
+	for _, platform := range env.Config.Platforms {
+		ctx := env.platformContext(platform)
+		buildPkg, err := ctx.ImportDir(pkgCtx.BuildPackage.Dir, build.ImportComment)
+		if _, ok := err.(*build.NoGoError); ok { // No files for this platform.
+			continue
+		} else if err != nil {
+			return nil, nil, err
+		}
+		for _, file := range env.packageFiles(pkgCtx, buildPkg) {
+			filePath := path.Join(pkgCtx.Package.RealPath, file)
+			platforms[filePath] = append(platforms[filePath], platform)
+		}
+	}
+
+	files := make([]string, 0, len(platforms))
+	for filePath := range platforms {
+		files = append(files, filePath)
+	}
+	sort.Strings(files)
+	return files, platforms, nil
+}
+
+// platformRefType is the RefType of a type declared only for some platforms,
+// when the package has other declarations of the type for other platforms.
+// Ex: `type stat struct` on stat_linux.go and stat_windows.go.
+type platformRefType struct {
+	platforms []Platform
+	refType   RefType
+}
+
+// refTypeForPlatforms returns the RefType of the type declared for the given
+// platforms. The RefType of the package (see RefTypeByName) is used unless it
+// is declared only for other platforms. In that case, a RefType for the
+// platforms is created, working as a reference to a type not declared yet.
+func (p *Package) refTypeForPlatforms(name string, platforms []Platform) (RefType, bool) {
+	refType, ok := p.RefTypeByName(name)
+	if !ok || platforms == nil || !disjointPlatforms(platformsOf(typeLocked(refType)), platforms) {
+		return refType, ok
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.platformRefType(name, platforms), true
+}
+
+// platformRefType returns the RefType of the type declared for the given
+// platforms, creating it if the type was not declared for them yet.
+func (p *Package) platformRefType(name string, platforms []Platform) RefType {
+	for _, prt := range p.platformRefTypes[name] {
+		if !disjointPlatforms(prt.platforms, platforms) {
+			return prt.refType
+		}
+	}
+	refType := NewRefType(name, p, NewBaseType(p, name))
+	p.addPlatformRefType(name, platforms, refType)
+	return refType
+}
+
+// movePlatformMethod moves a method declared before its type to the RefType
+// of the platforms of the method. It happens when the type is declared for
+// other platforms first. Ex: a method on attrs_windows.go parsed before the
+// type on stat_linux.go.
+func (p *Package) movePlatformMethod(name string, method *TypeMethod) {
+	p.mu.Lock()
+	refType := p.platformRefType(name, method.Descriptor.Platforms)
+	p.mu.Unlock()
+
+	typeLocked(refType).AddMethod(method)
+	if len(method.Descriptor.Recv) > 0 {
+		recv := &method.Descriptor.Recv[0]
+		if _, ok := recv.Type.(*StarRefType); ok {
+			recv.Type = NewStarRefType(refType)
+		} else {
+			recv.Type = refType
+		}
+	}
+}
+
+// declarePlatformType realizes the RefType of the type declared for the given
+// platforms, when the RefType of the package is bound to the declaration for
+// other platforms (see refTypeForPlatforms).
+func (p *Package) declarePlatformType(name string, platforms []Platform, t Type) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, prt := range p.platformRefTypes[name] {
+		bt, ok := typeLocked(prt.refType).(*BaseType)
+		if !ok || disjointPlatforms(prt.platforms, platforms) {
+			continue
+		}
+		// Methods declared before the type, see declareType.
+		for _, method := range bt.Methods() {
+			bindRecvTypeParams(method.Descriptor, t)
+			t.AddMethod(method)
+		}
+		prt.refType.AppendType(t)
+		prt.platforms = platforms
+		return
+	}
+	p.addPlatformRefType(name, platforms, NewRefType(name, p, t))
+}
+
+// declaredRefType returns the RefType bound to the type declared in the
+// package, including the RefTypes of the declarations for other platforms.
+func (p *Package) declaredRefType(t Type) (RefType, bool) {
+	if refType, ok := p.RefTypeByName(t.Name()); ok && typeLocked(refType) == t {
+		return refType, true
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	for _, prt := range p.platformRefTypes[t.Name()] {
+		if typeLocked(prt.refType) == t {
+			return prt.refType, true
+		}
+	}
+	return nil, false
+}
+
+func (p *Package) addPlatformRefType(name string, platforms []Platform, refType RefType) {
+	if p.platformRefTypes == nil {
+		p.platformRefTypes = make(map[string][]*platformRefType)
+	}
+	p.platformRefTypes[name] = append(p.platformRefTypes[name], &platformRefType{
+		platforms: platforms,
+		refType:   refType,
+	})
+}
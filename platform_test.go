@@ -0,0 +1,146 @@
+package myasthurts_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	myasthurts "github.com/jamillosantos/go-my-ast-hurts"
+)
+
+var _ = Describe("Platforms", func() {
+	var (
+		linux      = myasthurts.Platform{GOOS: "linux", GOARCH: "amd64"}
+		windows    = myasthurts.Platform{GOOS: "windows", GOARCH: "amd64"}
+		linuxDebug = myasthurts.Platform{GOOS: "linux", GOARCH: "amd64", Tags: []string{"debug"}}
+
+		env *myasthurts.Environment
+	)
+
+	BeforeEach(func() {
+		var err error
+		env, err = myasthurts.NewEnvironment()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should format the platform", func() {
+		Expect(linux.String()).To(Equal("linux/amd64"))
+		Expect(linuxDebug.String()).To(Equal("linux/amd64,debug"))
+	})
+
+	It("should parse only the files of the build context by default", func() {
+		env.BuildContext.GOOS = "windows"
+		pkg, err := env.ParseDir("./data/platforms")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(pkg.Files).To(HaveLen(4))
+		Expect(pkg.Files[0].Platforms).To(BeNil())
+		Expect(pkg.Structs).To(HaveLen(3))
+		Expect(pkg.Structs[0].Platforms).To(BeNil())
+		Expect(pkg.Variables).To(BeEmpty())
+	})
+
+	When("many platforms are configured", func() {
+		var pkg *myasthurts.Package
+
+		BeforeEach(func() {
+			env.Config.Platforms = []myasthurts.Platform{linux, windows, linuxDebug}
+
+			var err error
+			pkg, err = env.ParseDir("./data/platforms")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should parse the files of all platforms", func() {
+			Expect(pkg.Files).To(HaveLen(6))
+			Expect(pkg.Files[0].FileName).To(HaveSuffix("attrs_windows.go"))
+			Expect(pkg.Files[0].Platforms).To(Equal([]myasthurts.Platform{windows}))
+			Expect(pkg.Files[1].FileName).To(HaveSuffix("config.go"))
+			Expect(pkg.Files[1].Platforms).To(Equal([]myasthurts.Platform{linux, windows, linuxDebug}))
+			Expect(pkg.Files[2].FileName).To(HaveSuffix("debug.go"))
+			Expect(pkg.Files[2].Platforms).To(Equal([]myasthurts.Platform{linuxDebug}))
+			Expect(pkg.Files[3].FileName).To(HaveSuffix("stat_linux.go"))
+			Expect(pkg.Files[3].Platforms).To(Equal([]myasthurts.Platform{linux, linuxDebug}))
+			Expect(pkg.Files[4].FileName).To(HaveSuffix("stat_windows.go"))
+			Expect(pkg.Files[4].Platforms).To(Equal([]myasthurts.Platform{windows}))
+			Expect(pkg.Files[5].FileName).To(HaveSuffix("watch_windows.go"))
+		})
+
+		It("should record the platforms of the declarations", func() {
+			config, ok := pkg.StructByName("Config")
+			Expect(ok).To(BeTrue())
+			Expect(config.Platforms).To(HaveLen(3))
+
+			load, ok := pkg.MethodByName("Load")
+			Expect(ok).To(BeTrue())
+			Expect(load.Platforms).To(HaveLen(3))
+
+			Expect(pkg.Variables).To(HaveLen(1))
+			Expect(pkg.Variables[0].Name).To(Equal("Debug"))
+			Expect(pkg.Variables[0].Platforms).To(Equal([]myasthurts.Platform{linuxDebug}))
+		})
+
+		It("should keep the declarations of each platform", func() {
+			Expect(pkg.Structs).To(HaveLen(4))
+
+			linuxStat := pkg.Structs[1]
+			Expect(linuxStat.Name()).To(Equal("stat"))
+			Expect(linuxStat.Fields).To(HaveLen(1))
+			Expect(linuxStat.Platforms).To(Equal([]myasthurts.Platform{linux, linuxDebug}))
+
+			windowsStat := pkg.Structs[2]
+			Expect(windowsStat.Name()).To(Equal("stat"))
+			Expect(windowsStat.Fields).To(HaveLen(2))
+			Expect(windowsStat.Platforms).To(Equal([]myasthurts.Platform{windows}))
+
+			Expect(pkg.Structs[3].Name()).To(Equal("watcher"))
+
+			Expect(pkg.Constants).To(HaveLen(2))
+			Expect(pkg.Constants[0].Value.ExactString()).To(Equal("47"))
+			Expect(pkg.Constants[0].Platforms).To(Equal([]myasthurts.Platform{linux, linuxDebug}))
+			Expect(pkg.Constants[1].Value.ExactString()).To(Equal("92"))
+			Expect(pkg.Constants[1].Platforms).To(Equal([]myasthurts.Platform{windows}))
+		})
+
+		It("should bind the types of each platform", func() {
+			linuxStat, windowsStat := pkg.Structs[1], pkg.Structs[2]
+
+			Expect(linuxStat.Methods()).To(HaveLen(1))
+			Expect(linuxStat.Methods()[0].Descriptor.Platforms).To(Equal([]myasthurts.Platform{linux, linuxDebug}))
+			Expect(windowsStat.Methods()).To(HaveLen(2))
+			Expect(windowsStat.MethodsMap()).To(HaveKey("attrs"))
+			Expect(windowsStat.MethodsMap()["attrs"].Descriptor.Recv[0].Type.Type()).To(BeIdenticalTo(windowsStat))
+			Expect(windowsStat.MethodsMap()["id"].Descriptor.Platforms).To(Equal([]myasthurts.Platform{windows}))
+
+			refType, ok := pkg.RefTypeByName("stat")
+			Expect(ok).To(BeTrue())
+			Expect(refType.Type()).To(BeIdenticalTo(linuxStat))
+
+			watcher := pkg.Structs[3]
+			Expect(watcher.Fields[0].RefType.Type()).To(BeIdenticalTo(windowsStat))
+		})
+	})
+
+	When("a platform specific file imports a package of its platform", func() {
+		BeforeEach(func() {
+			env.Config.Platforms = []myasthurts.Platform{linux, windows}
+		})
+
+		It("should find the imported package with the build context of the file", func() {
+			pkg, err := env.ParseDir("./data/platformimports")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pkg.Files).To(HaveLen(2))
+			Expect(pkg.Files[1].Imports).To(HaveLen(1))
+			Expect(pkg.Files[1].Imports[0].Package.Name).To(Equal("winapi"))
+
+			handle, ok := pkg.StructByName("fileHandle")
+			Expect(ok).To(BeTrue())
+			Expect(handle.Fields[0].RefType.Pkg().ImportPath).To(HaveSuffix("/platformimports/winapi"))
+		})
+
+		It("should parse the standard library", func() {
+			pkg, err := env.Parse("os")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pkg.Explored).To(BeTrue())
+		})
+	})
+})
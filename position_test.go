@@ -0,0 +1,105 @@
+package myasthurts_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	myasthurts "github.com/jamillosantos/go-my-ast-hurts"
+)
+
+var _ = Describe("Positions", func() {
+	const fileName = "data/models29.sample.go"
+
+	var pkg *myasthurts.Package
+
+	BeforeEach(func() {
+		env, err := myasthurts.NewEnvironment()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(env.ParseFile(newDataPackageContext(env), fileName)).To(Succeed())
+
+		var ok bool
+		pkg, ok = env.PackageByImportPath("data")
+		Expect(ok).To(BeTrue())
+	})
+
+	expectPosition := func(pos myasthurts.Position, startLine, startColumn, endLine, endColumn int) {
+		ExpectWithOffset(1, pos.Start.Filename).To(Equal(fileName))
+		ExpectWithOffset(1, pos.Start.Line).To(Equal(startLine))
+		ExpectWithOffset(1, pos.Start.Column).To(Equal(startColumn))
+		ExpectWithOffset(1, pos.End.Filename).To(Equal(fileName))
+		ExpectWithOffset(1, pos.End.Line).To(Equal(endLine))
+		ExpectWithOffset(1, pos.End.Column).To(Equal(endColumn))
+		ExpectWithOffset(1, pos.End.Offset).To(BeNumerically(">", pos.Start.Offset))
+	}
+
+	It("should set the position of constants and variables", func() {
+		c, ok := pkg.ConstantByName("Limit")
+		Expect(ok).To(BeTrue())
+		expectPosition(c.Position, 4, 7, 4, 17)
+
+		Expect(pkg.Variables).To(HaveLen(3))
+		expectPosition(pkg.Variables[0].Position, 6, 5, 6, 21)
+	})
+
+	It("should set the position of structs and fields", func() {
+		s, ok := pkg.StructByName("User")
+		Expect(ok).To(BeTrue())
+		expectPosition(s.Position, 8, 6, 11, 2)
+		expectPosition(s.Fields[0].Position, 9, 2, 9, 27)
+		expectPosition(s.Fields[1].Position, 10, 2, 10, 10)
+	})
+
+	It("should set the position of interfaces and their methods", func() {
+		i, ok := pkg.InterfaceByName("Finder")
+		Expect(ok).To(BeTrue())
+		expectPosition(i.Position, 13, 6, 15, 2)
+
+		find := i.Methods()[0].Descriptor
+		expectPosition(find.Position, 14, 2, 14, 34)
+		expectPosition(find.Arguments[0].Position, 14, 7, 14, 18)
+		expectPosition(find.Result[0].Position, 14, 21, 14, 26)
+	})
+
+	It("should set the position of methods and their arguments", func() {
+		s, ok := pkg.StructByName("User")
+		Expect(ok).To(BeTrue())
+		rename := s.MethodsMap()["Rename"].Descriptor
+		expectPosition(rename.Position, 17, 1, 20, 2)
+		expectPosition(rename.Recv[0].Position, 17, 7, 17, 14)
+		expectPosition(rename.Arguments[0].Position, 17, 23, 17, 34)
+		expectPosition(rename.Result[0].Position, 17, 36, 17, 41)
+	})
+
+	It("should set the position of each name declared together", func() {
+		// Each name starts its own position, which ends with the declaration
+		// as the positions of single names do.
+		minAge, ok := pkg.ConstantByName("MinAge")
+		Expect(ok).To(BeTrue())
+		expectPosition(minAge.Position, 22, 7, 22, 30)
+		maxAge, ok := pkg.ConstantByName("MaxAge")
+		Expect(ok).To(BeTrue())
+		expectPosition(maxAge.Position, 22, 15, 22, 30)
+
+		expectPosition(pkg.VariableByName("first").Position, 24, 5, 24, 23)
+		expectPosition(pkg.VariableByName("last").Position, 24, 12, 24, 23)
+
+		point, ok := pkg.StructByName("Point")
+		Expect(ok).To(BeTrue())
+		expectPosition(point.Fields[0].Position, 27, 2, 27, 14)
+		expectPosition(point.Fields[1].Position, 27, 5, 27, 14)
+
+		move, ok := pkg.MethodByName("Move")
+		Expect(ok).To(BeTrue())
+		expectPosition(move.Arguments[0].Position, 30, 11, 30, 19)
+		expectPosition(move.Arguments[1].Position, 30, 21, 30, 35)
+		expectPosition(move.Arguments[2].Position, 30, 25, 30, 35)
+		expectPosition(move.Result[0].Position, 30, 38, 30, 50)
+		expectPosition(move.Result[1].Position, 30, 41, 30, 50)
+
+		mover, ok := pkg.InterfaceByName("Mover")
+		Expect(ok).To(BeTrue())
+		m := mover.Methods()[0].Descriptor
+		expectPosition(m.Arguments[0].Position, 35, 7, 35, 21)
+		expectPosition(m.Arguments[1].Position, 35, 11, 35, 21)
+	})
+})
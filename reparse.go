@@ -0,0 +1,298 @@
+package myasthurts
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Invalidate removes the declarations of the file from its package. The
+// RefTypes of the types declared by the file are kept, pointing to
+// placeholder BaseTypes, as the references to types not declared yet. So,
+// the references from other files and packages are linked again when the
+// file is parsed (see Reparse). Methods declared by other files on those
+// types are kept on the placeholders.
+//
+// It returns ErrFileNotFound if the file was not parsed.
+func (env *Environment) Invalidate(filePath string) error {
+	env.parseMu.Lock()
+	defer env.parseMu.Unlock()
+
+	file, ok := env.fileByPath(filePath)
+	if !ok {
+		return errors.Wrap(ErrFileNotFound, filePath)
+	}
+	file.Package.removeFile(file)
+	return nil
+}
+
+// Reparse parses the file again, replacing the declarations it had on its
+// package (see Invalidate). Files not parsed before are added to the package
+// of their directory. The build constraints of every file are checked again:
+// files are parsed only if they are built for the build context or for any
+// of the configured platforms (see EnvConfig.Platforms), otherwise they are
+// removed from their packages, as the files that do not exist anymore.
+// External test files are added to Package.XTest, when EnvConfig.IncludeTests
+// is set. The file is moved to the end of Package.Files.
+//
+// The constants of the package are evaluated again, as they may refer to
+// the constants of the file. The lengths of arrays already evaluated, and
+// the declarations of other packages, are not updated.
+//
+// Files are not type checked, even if EnvConfig.TypeCheck is set.
+func (env *Environment) Reparse(filePath string) error {
+	env.parseMu.Lock()
+	defer env.parseMu.Unlock()
+
+	var pkg *Package
+	if file, ok := env.fileByPath(filePath); ok {
+		file.Package.removeFile(file)
+		pkg, filePath = file.Package, file.FileName
+		if pkg.ForTest != nil { // The file may not be an external test file anymore.
+			pkg = pkg.ForTest
+		}
+	} else {
+		absPath, err := filepath.Abs(filePath)
+		if err != nil {
+			return err
+		}
+		if pkg, ok = env.packageByDir(filepath.Dir(absPath)); !ok {
+			return errors.Wrapf(ErrPackageNotFound, "for %s", filePath)
+		}
+		filePath = path.Join(pkg.RealPath, filepath.Base(absPath))
+	}
+	if _, err := os.Stat(filePath); os.IsNotExist(err) { // The file was removed.
+		return nil
+	}
+
+	// The build constraints of the file may have changed.
+	pkgCtx, err := env.fileContext(pkg, filePath)
+	if err != nil || pkgCtx == nil {
+		return err
+	}
+	return env.parseFile(pkgCtx, filePath)
+}
+
+// fileContext returns the context for parsing a file of the package. The
+// context is nil for files that would not be parsed with the package: files
+// not built for the build context nor for any of the configured platforms,
+// and test files when the tests of the package are not included.
+func (env *Environment) fileContext(pkg *Package, filePath string) (*ParsePackageContext, error) {
+	dir, name := filepath.Split(filePath)
+
+	var platforms []Platform
+	if len(env.Config.Platforms) == 0 {
+		if ok, err := env.BuildContext.MatchFile(dir, name); err != nil || !ok {
+			return nil, err
+		}
+	} else {
+		for _, platform := range env.Config.Platforms {
+			ctx := env.platformContext(platform)
+			ok, err := ctx.MatchFile(dir, name)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				platforms = append(platforms, platform)
+			}
+		}
+		if len(platforms) == 0 {
+			return nil, nil
+		}
+	}
+
+	pkgCtx := env.packageContext(pkg, pkg.BuildInfo)
+	if strings.HasSuffix(name, "_test.go") {
+		if !pkgCtx.tests {
+			return nil, nil
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), filePath, nil, parser.PackageClauseOnly)
+		if err != nil {
+			return nil, err
+		}
+		// The same rule go/build uses for external test files.
+		if pkg.BuildInfo != nil && file.Name.Name != pkg.Name && strings.HasSuffix(file.Name.Name, "_test") {
+			pkgCtx = env.xtestContext(pkg, *pkg.BuildInfo)
+		}
+	}
+	if platforms != nil {
+		pkgCtx.platforms = map[string][]Platform{filePath: platforms}
+	}
+	return pkgCtx, nil
+}
+
+// fileByPath finds the File model of the file in the packages of the
+// environment.
+func (env *Environment) fileByPath(filePath string) (*File, bool) {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil, false
+	}
+
+	env.mu.RLock()
+	defer env.mu.RUnlock()
+	for _, pkg := range env.packages {
+		for _, file := range pkg.Files {
+			if fileAbsPath, err := filepath.Abs(file.FileName); err == nil && fileAbsPath == absPath {
+				return file, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// removeFile removes the declarations of the file from the package.
+func (p *Package) removeFile(file *File) {
+	// Methods are removed from their receivers first, so the types keep only
+	// the methods declared by other files.
+	for _, method := range file.Methods {
+		if len(method.Recv) == 0 {
+			continue
+		}
+		if t, ok := typeLocked(method.Recv[0].Type).(interface {
+			RemoveMethod(*MethodDescriptor) bool
+		}); ok {
+			t.RemoveMethod(method)
+		}
+	}
+
+	types := make(map[Type]bool, len(file.Types))
+	for _, t := range file.Types {
+		types[t] = true
+		refType, ok := p.declaredRefType(t)
+		if !ok {
+			continue
+		}
+		// The RefType goes back to be a reference to a type not declared
+		// yet, as the ones for methods declared before their types.
+		placeholder := NewBaseType(p, t.Name())
+		for _, method := range declaredMethods(t) {
+			placeholder.AddMethod(method)
+		}
+		refType.AppendType(placeholder)
+	}
+
+	methods := make(map[*MethodDescriptor]bool, len(file.Methods))
+	for _, method := range file.Methods {
+		methods[method] = true
+	}
+	variables := make(map[*Variable]bool, len(file.Variables))
+	for _, variable := range file.Variables {
+		variables[variable] = true
+	}
+	constants := make(map[*Constant]bool, len(file.Constants))
+	for _, c := range file.Constants {
+		constants[c] = true
+	}
+
+	p.mu.Lock()
+	typesList := p.Types[:0:0]
+	for _, t := range p.Types {
+		if !types[t] {
+			typesList = append(typesList, t)
+		}
+	}
+	p.Types = typesList
+	structs := p.Structs[:0:0]
+	for _, s := range p.Structs {
+		if !types[s] {
+			structs = append(structs, s)
+		}
+	}
+	p.Structs = structs
+	interfaces := p.Interfaces[:0:0]
+	for _, i := range p.Interfaces {
+		if !types[i] {
+			interfaces = append(interfaces, i)
+		}
+	}
+	p.Interfaces = interfaces
+	namedTypes := p.NamedTypes[:0:0]
+	for _, t := range p.NamedTypes {
+		if !types[t] {
+			namedTypes = append(namedTypes, t)
+		}
+	}
+	p.NamedTypes = namedTypes
+	aliases := p.Aliases[:0:0]
+	for _, a := range p.Aliases {
+		if !types[a] {
+			aliases = append(aliases, a)
+		}
+	}
+	p.Aliases = aliases
+
+	funcs := p.Methods[:0:0]
+	for _, method := range p.Methods {
+		if !methods[method] {
+			funcs = append(funcs, method)
+		}
+	}
+	p.Methods = funcs
+	p.MethodsMap = make(map[string]*MethodDescriptor, len(p.Methods))
+	for _, method := range p.Methods {
+		p.MethodsMap[method.Name()] = method
+	}
+
+	vars := p.Variables[:0:0]
+	for _, variable := range p.Variables {
+		if !variables[variable] {
+			vars = append(vars, variable)
+		}
+	}
+	p.Variables = vars
+	consts := p.Constants[:0:0]
+	for _, c := range p.Constants {
+		if !constants[c] {
+			consts = append(consts, c)
+		}
+	}
+	p.Constants = consts
+
+	arrays := p.arrays[:0:0]
+	for _, array := range p.arrays {
+		if array.ctx == nil || array.ctx.file != file {
+			arrays = append(arrays, array)
+		}
+	}
+	p.arrays = arrays
+	pendingInterfaces := p.interfaces[:0:0]
+	for _, i := range p.interfaces {
+		if !types[i] {
+			pendingInterfaces = append(pendingInterfaces, i)
+		}
+	}
+	p.interfaces = pendingInterfaces
+
+	files := p.Files[:0:0]
+	for _, f := range p.Files {
+		if f != file {
+			files = append(files, f)
+		}
+	}
+	p.Files = files
+	p.mu.Unlock()
+
+	// The constants referring to the ones of the file are evaluated again.
+	p.resetConstants()
+	p.resolveConstants()
+}
+
+// declaredMethods returns the methods declared on the type itself. Methods
+// of interfaces and of embedded types are not included.
+func declaredMethods(t Type) []*TypeMethod {
+	switch tt := t.(type) {
+	case *Struct:
+		return tt.BaseType.Methods()
+	case *NamedType:
+		return tt.BaseType.Methods()
+	case *Alias:
+		return tt.BaseType.Methods()
+	}
+	return nil
+}
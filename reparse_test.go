@@ -0,0 +1,299 @@
+package myasthurts_test
+
+import (
+	"errors"
+	"go/constant"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	myasthurts "github.com/jamillosantos/go-my-ast-hurts"
+)
+
+var _ = Describe("Reparse", func() {
+	var (
+		env    *myasthurts.Environment
+		pkg    *myasthurts.Package
+		pkgDir string
+	)
+
+	writeFile := func(name, src string) {
+		Expect(os.WriteFile(filepath.Join(pkgDir, name), []byte(src), 0644)).To(Succeed())
+	}
+
+	methodNames := func(t myasthurts.Type) []string {
+		names := make([]string, 0)
+		for _, m := range t.Methods() {
+			names = append(names, m.Name)
+		}
+		return names
+	}
+
+	BeforeEach(func() {
+		pkgDir = GinkgoT().TempDir()
+		for _, name := range []string{"user.go", "greet.go"} {
+			src, err := os.ReadFile(filepath.Join("data/reparse", name))
+			Expect(err).ToNot(HaveOccurred())
+			writeFile(name, string(src))
+		}
+
+		var err error
+		env, err = myasthurts.NewEnvironment()
+		Expect(err).ToNot(HaveOccurred())
+		pkg, err = env.ParseDir(pkgDir)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pkg.Files).To(HaveLen(2))
+	})
+
+	Describe("Invalidate", func() {
+		It("should remove the declarations of the file", func() {
+			Expect(env.Invalidate(filepath.Join(pkgDir, "user.go"))).To(Succeed())
+
+			Expect(pkg.Files).To(HaveLen(1))
+			Expect(pkg.Files[0].FileName).To(HaveSuffix("greet.go"))
+			Expect(pkg.Structs).To(HaveLen(1))
+			Expect(pkg.Structs[0].Name()).To(Equal("Admin"))
+			Expect(pkg.Types).To(HaveLen(1))
+			Expect(pkg.Constants).To(BeEmpty())
+			_, ok := pkg.MethodByName("NewUser")
+			Expect(ok).To(BeFalse())
+			Expect(pkg.Methods).To(BeEmpty())
+		})
+
+		It("should keep the references to the types of the file", func() {
+			Expect(env.Invalidate(filepath.Join(pkgDir, "user.go"))).To(Succeed())
+
+			refType, ok := pkg.RefTypeByName("User")
+			Expect(ok).To(BeTrue())
+			Expect(refType.Type()).To(BeAssignableToTypeOf(&myasthurts.BaseType{}))
+			// Methods declared by other files are kept.
+			Expect(methodNames(refType.Type())).To(Equal([]string{"Greet"}))
+			Expect(pkg.Variables[0].RefType).To(BeIdenticalTo(refType))
+		})
+
+		It("should fail for files not parsed", func() {
+			err := env.Invalidate(filepath.Join(pkgDir, "unknown.go"))
+			Expect(errors.Is(err, myasthurts.ErrFileNotFound)).To(BeTrue())
+		})
+	})
+
+	It("should replace the declarations of the file", func() {
+		writeFile("user.go", `package reparse
+
+const MaxNameLen = 64
+
+// User is a user of the application.
+type User struct {
+	Name  string
+	Email string
+}
+
+func (u *User) SetEmail(email string) {
+	u.Email = email
+}
+`)
+		Expect(env.Reparse(filepath.Join(pkgDir, "user.go"))).To(Succeed())
+
+		Expect(pkg.Files).To(HaveLen(2))
+		Expect(pkg.Files[1].FileName).To(HaveSuffix("user.go"))
+		Expect(pkg.Constants).To(HaveLen(1))
+		Expect(pkg.Constants[0].Value.ExactString()).To(Equal("64"))
+		Expect(pkg.Methods).To(BeEmpty())
+
+		user, ok := pkg.StructByName("User")
+		Expect(ok).To(BeTrue())
+		Expect(user.Fields).To(HaveLen(2))
+		Expect(methodNames(user)).To(ConsistOf("Greet", "SetEmail"))
+
+		// References from other files are linked to the new declaration.
+		Expect(pkg.Variables[0].RefType.Type()).To(BeIdenticalTo(user))
+		admin, ok := pkg.StructByName("Admin")
+		Expect(ok).To(BeTrue())
+		Expect(admin.Fields[0].RefType.Type()).To(BeIdenticalTo(user))
+		Expect(admin.AllFields()).To(HaveLen(4))
+	})
+
+	It("should replace the methods declared on types of other files", func() {
+		writeFile("greet.go", `package reparse
+
+var DefaultUser User
+
+func (u User) Hello() string {
+	return "Hi, " + u.Name
+}
+`)
+		Expect(env.Reparse(filepath.Join(pkgDir, "greet.go"))).To(Succeed())
+
+		user, ok := pkg.StructByName("User")
+		Expect(ok).To(BeTrue())
+		Expect(methodNames(user)).To(Equal([]string{"Rename", "Hello"}))
+		Expect(user.MethodsMap()).ToNot(HaveKey("Greet"))
+		_, ok = pkg.StructByName("Admin")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should add new files", func() {
+		writeFile("role.go", `package reparse
+
+type Role string
+`)
+		Expect(env.Reparse(filepath.Join(pkgDir, "role.go"))).To(Succeed())
+
+		Expect(pkg.Files).To(HaveLen(3))
+		_, ok := pkg.NamedTypeByName("Role")
+		Expect(ok).To(BeTrue())
+	})
+
+	It("should not add new files out of the build context", func() {
+		writeFile("role.go", `//go:build ignore
+
+package reparse
+
+type Role string
+`)
+		writeFile("user_test.go", `package reparse
+
+type testUser User
+`)
+		Expect(env.Reparse(filepath.Join(pkgDir, "role.go"))).To(Succeed())
+		Expect(env.Reparse(filepath.Join(pkgDir, "user_test.go"))).To(Succeed())
+
+		Expect(pkg.Files).To(HaveLen(2))
+		Expect(pkg.Types).To(HaveLen(2))
+	})
+
+	It("should remove the files excluded from the build context", func() {
+		writeFile("greet.go", `//go:build ignore
+
+package reparse
+
+var DefaultUser User
+`)
+		Expect(env.Reparse(filepath.Join(pkgDir, "greet.go"))).To(Succeed())
+
+		Expect(pkg.Files).To(HaveLen(1))
+		Expect(pkg.Files[0].FileName).To(HaveSuffix("user.go"))
+		Expect(pkg.Variables).To(BeEmpty())
+		_, ok := pkg.StructByName("Admin")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should update the platforms of the files parsed before", func() {
+		linux := myasthurts.Platform{GOOS: "linux", GOARCH: "amd64"}
+		windows := myasthurts.Platform{GOOS: "windows", GOARCH: "amd64"}
+		env, err := myasthurts.NewEnvironmentWithConfig(myasthurts.EnvConfig{
+			Platforms: []myasthurts.Platform{linux, windows},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		pkg, err := env.ParseDir(pkgDir)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pkg.Files[0].Platforms).To(Equal([]myasthurts.Platform{linux, windows}))
+
+		writeFile("greet.go", `//go:build windows
+
+package reparse
+
+var DefaultUser User
+`)
+		Expect(env.Reparse(filepath.Join(pkgDir, "greet.go"))).To(Succeed())
+
+		Expect(pkg.Files).To(HaveLen(2))
+		Expect(pkg.Files[1].FileName).To(HaveSuffix("greet.go"))
+		Expect(pkg.Files[1].Platforms).To(Equal([]myasthurts.Platform{windows}))
+		Expect(pkg.Variables).To(HaveLen(1))
+		Expect(pkg.Variables[0].Platforms).To(Equal([]myasthurts.Platform{windows}))
+	})
+
+	It("should add new files for the platforms they are built for", func() {
+		linux := myasthurts.Platform{GOOS: "linux", GOARCH: "amd64"}
+		windows := myasthurts.Platform{GOOS: "windows", GOARCH: "amd64"}
+		env, err := myasthurts.NewEnvironmentWithConfig(myasthurts.EnvConfig{
+			Platforms: []myasthurts.Platform{linux, windows},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		pkg, err := env.ParseDir(pkgDir)
+		Expect(err).ToNot(HaveOccurred())
+
+		writeFile("role_windows.go", `package reparse
+
+type Role string
+`)
+		writeFile("role_plan9.go", `package reparse
+
+type Role int
+`)
+		Expect(env.Reparse(filepath.Join(pkgDir, "role_windows.go"))).To(Succeed())
+		Expect(env.Reparse(filepath.Join(pkgDir, "role_plan9.go"))).To(Succeed())
+
+		Expect(pkg.Files).To(HaveLen(3))
+		Expect(pkg.Files[2].FileName).To(HaveSuffix("role_windows.go"))
+		Expect(pkg.Files[2].Platforms).To(Equal([]myasthurts.Platform{windows}))
+		role, ok := pkg.NamedTypeByName("Role")
+		Expect(ok).To(BeTrue())
+		Expect(role.Platforms).To(Equal([]myasthurts.Platform{windows}))
+	})
+
+	It("should add new external test files to the external test package", func() {
+		env, err := myasthurts.NewEnvironmentWithConfig(myasthurts.EnvConfig{IncludeTests: true})
+		Expect(err).ToNot(HaveOccurred())
+		pkg, err := env.ParseDir(pkgDir)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pkg.XTest).To(BeNil())
+
+		writeFile("user_test.go", `package reparse
+
+type testUser User
+`)
+		writeFile("greet_test.go", `package reparse_test
+
+type greeter struct{}
+`)
+		Expect(env.Reparse(filepath.Join(pkgDir, "user_test.go"))).To(Succeed())
+		Expect(env.Reparse(filepath.Join(pkgDir, "greet_test.go"))).To(Succeed())
+
+		Expect(pkg.Files).To(HaveLen(3))
+		Expect(pkg.Files[2].Test).To(BeTrue())
+		Expect(pkg.XTest).ToNot(BeNil())
+		Expect(pkg.XTest.ForTest).To(BeIdenticalTo(pkg))
+		Expect(pkg.XTest.Files).To(HaveLen(1))
+		Expect(pkg.XTest.Files[0].FileName).To(HaveSuffix("greet_test.go"))
+		_, ok := pkg.XTest.StructByName("greeter")
+		Expect(ok).To(BeTrue())
+		_, ok = pkg.StructByName("greeter")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should evaluate the constants referring to the file again", func() {
+		writeFile("greet.go", `package reparse
+
+const MaxEmailLen = MaxNameLen * 2
+`)
+		Expect(env.Reparse(filepath.Join(pkgDir, "greet.go"))).To(Succeed())
+		maxEmailLen, ok := pkg.ConstantByName("MaxEmailLen")
+		Expect(ok).To(BeTrue())
+		Expect(maxEmailLen.Value.ExactString()).To(Equal("64"))
+
+		writeFile("user.go", `package reparse
+
+const MaxNameLen = 64
+`)
+		Expect(env.Reparse(filepath.Join(pkgDir, "user.go"))).To(Succeed())
+		Expect(maxEmailLen.Value.ExactString()).To(Equal("128"))
+
+		Expect(env.Invalidate(filepath.Join(pkgDir, "user.go"))).To(Succeed())
+		Expect(maxEmailLen.Value.Kind()).To(Equal(constant.Unknown))
+	})
+
+	It("should remove the files deleted", func() {
+		Expect(os.Remove(filepath.Join(pkgDir, "greet.go"))).To(Succeed())
+		Expect(env.Reparse(filepath.Join(pkgDir, "greet.go"))).To(Succeed())
+
+		Expect(pkg.Files).To(HaveLen(1))
+		Expect(pkg.Variables).To(BeEmpty())
+		user, ok := pkg.StructByName("User")
+		Expect(ok).To(BeTrue())
+		Expect(methodNames(user)).To(Equal([]string{"Rename"}))
+	})
+})
@@ -0,0 +1,109 @@
+package myasthurts
+
+import "github.com/pkg/errors"
+
+// Resolve returns the Type the RefType refers to, parsing its package if it
+// was not explored yet. Differently from RefType.Type, it does not depend on
+// EnvConfig.Resolve nor on the depth limit, and it reports the errors found
+// while parsing the package.
+func (env *Environment) Resolve(ref RefType) (Type, error) {
+	env.parseMu.Lock()
+	defer env.parseMu.Unlock()
+
+	if pkg := ref.Pkg(); pkg != nil && typeLocked(ref) == nil {
+		if err := pkg.resolveLocked(true); err != nil {
+			return nil, err
+		}
+	}
+	t := typeLocked(ref)
+	if t == nil {
+		return nil, errors.Wrap(ErrTypeNotFound, ref.Name())
+	}
+	return t, nil
+}
+
+// typeLocked returns the Type the RefType refers to, like RefType.Type, for
+// callers holding the parse lock of the environment. Composite RefTypes
+// (Ex: `*T`, `[]T`) are followed until the RefType of the named type.
+func typeLocked(ref RefType) Type {
+	for {
+		switch r := ref.(type) {
+		case *BaseRefType:
+			return r.typeLocked()
+		case *StarRefType:
+			ref = r.RefType
+		case *SliceRefType:
+			ref = r.RefType
+		case *ArrayRefType:
+			ref = r.RefType
+		case *ChanRefType:
+			ref = r.RefType
+		case *EllipsisRefType:
+			ref = r.RefType
+		case *InstanceRefType:
+			ref = r.RefType
+		default:
+			return ref.Type()
+		}
+	}
+}
+
+// resolve parses the package on demand, if the environment has
+// EnvConfig.Resolve set. It waits for the other goroutines parsing, so it
+// must not be called with the parse lock held, see resolveLocked.
+func (p *Package) resolve() error {
+	if p.env == nil || !p.env.Config.Resolve {
+		return nil
+	}
+	p.env.parseMu.Lock()
+	defer p.env.parseMu.Unlock()
+	return p.resolveLocked(false)
+}
+
+// isLoading checks if the package is being parsed. Differently from the
+// other fields, loading is guarded by mu, as it is read while other goroutines
+// parse the package.
+func (p *Package) isLoading() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.loading > 0
+}
+
+// addLoading adds delta to the count of files of the package being parsed.
+func (p *Package) addLoading(delta int) {
+	p.mu.Lock()
+	p.loading += delta
+	p.mu.Unlock()
+}
+
+// resolveLocked parses the package on demand with the parse lock already
+// held. Unless forced, it only happens if the environment has
+// EnvConfig.Resolve set and the package is within the
+// EnvConfig.MaxResolveDepth.
+//
+// Packages already explored, being parsed or with files parsed directly are
+// not resolved.
+func (p *Package) resolveLocked(force bool) error {
+	if p.env == nil {
+		return nil
+	}
+	if !force {
+		config := p.env.Config
+		if !config.Resolve {
+			return nil
+		}
+		if config.MaxResolveDepth > 0 && p.depth > config.MaxResolveDepth {
+			return nil
+		}
+	}
+	if p.resolveErr != nil {
+		return p.resolveErr
+	}
+	if p.Explored || p.isLoading() || len(p.Files) > 0 || p.BuildInfo == nil {
+		return nil
+	}
+	if err := p.env.parsePackage(p.env.packageContext(p, p.BuildInfo)); err != nil {
+		p.resolveErr = errors.Wrapf(err, "could not resolve %s", p.ImportPath)
+	}
+	return p.resolveErr
+}
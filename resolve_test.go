@@ -0,0 +1,102 @@
+package myasthurts_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	myasthurts "github.com/jamillosantos/go-my-ast-hurts"
+)
+
+var _ = Describe("Resolution of imported packages", func() {
+	var env *myasthurts.Environment
+
+	BeforeEach(func() {
+		var err error
+		env, err = myasthurts.NewEnvironment()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	entry := func() *myasthurts.Struct {
+		Expect(env.ParseFile(newDataPackageContext(env), "data/models33.sample.go")).To(Succeed())
+		pkg, ok := env.PackageByImportPath("data")
+		Expect(ok).To(BeTrue())
+		s, ok := pkg.StructByName("Entry")
+		Expect(ok).To(BeTrue())
+		return s
+	}
+
+	// bufio.Reader has a `rd io.Reader` field, io is 2 imports away from the
+	// data package.
+	ioReader := func(s *myasthurts.Struct) myasthurts.RefType {
+		reader, ok := s.Fields[1].RefType.Type().(*myasthurts.Struct)
+		Expect(ok).To(BeTrue())
+		for _, field := range reader.Fields {
+			if field.Name == "rd" {
+				return field.RefType
+			}
+		}
+		Fail("rd field not found")
+		return nil
+	}
+
+	It("should not resolve imported packages by default", func() {
+		s := entry()
+		Expect(s.Fields[0].RefType.Type()).To(BeNil())
+
+		timePkg, ok := env.PackageByImportPath("time")
+		Expect(ok).To(BeTrue())
+		Expect(timePkg.Explored).To(BeFalse())
+	})
+
+	It("should resolve imported packages on the first access", func() {
+		env.Config.Resolve = true
+		s := entry()
+
+		timePkg, ok := env.PackageByImportPath("time")
+		Expect(ok).To(BeTrue())
+		Expect(timePkg.Explored).To(BeFalse())
+
+		t, ok := s.Fields[0].RefType.Type().(*myasthurts.Struct)
+		Expect(ok).To(BeTrue())
+		Expect(t.Name()).To(Equal("Time"))
+		Expect(t.Package()).To(Equal(timePkg))
+		Expect(timePkg.Explored).To(BeTrue())
+
+		Expect(ioReader(s).Type()).To(BeAssignableToTypeOf(&myasthurts.Interface{}))
+	})
+
+	It("should respect the depth limit", func() {
+		env.Config.Resolve = true
+		env.Config.MaxResolveDepth = 1
+		s := entry()
+
+		Expect(s.Fields[0].RefType.Type()).ToNot(BeNil())
+		rd := ioReader(s)
+		Expect(rd.Type()).To(BeNil())
+
+		By("resolving explicitly")
+		t, err := env.Resolve(rd)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(t).To(BeAssignableToTypeOf(&myasthurts.Interface{}))
+		Expect(rd.Type()).To(Equal(t))
+	})
+
+	It("should resolve explicitly without the resolution enabled", func() {
+		s := entry()
+
+		t, err := env.Resolve(s.Fields[0].RefType)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(t.Name()).To(Equal("Time"))
+	})
+
+	It("should fail resolving types that do not exist", func() {
+		env.Config.Resolve = true
+		entry()
+
+		timePkg, ok := env.PackageByImportPath("time")
+		Expect(ok).To(BeTrue())
+		ref, _ := timePkg.EnsureRefType("Nonexistent")
+		_, err := env.Resolve(ref)
+		Expect(err).To(MatchError(ContainSubstring("type not found")))
+	})
+})
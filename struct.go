@@ -2,8 +2,12 @@ package myasthurts
 
 type Struct struct {
 	BaseType
-	Doc    Doc
-	Fields []*Field
+	Doc        Doc
+	TypeParams []*TypeParam
+	Fields     []*Field
+	Position   Position
+	// Platforms where the struct exists, see Platform.
+	Platforms []Platform
 }
 
 // NewStruct return new pointer Struct
@@ -24,21 +28,163 @@ func (s *Struct) Name() string {
 	return s.name
 }
 
-// Implements checks if this struct implements a given interface.
+// Implements checks if values of this struct implement a given interface.
 //
 // This method uses the `MethodDescriptor.Compatible` to check if all interface
-// methods have are implemented on the struct.
-//
-// TODO(jota): Take into consideration Interface composing...
+// methods, including the ones from embedded interfaces, are implemented on the
+// struct. Only methods with value receivers are taken into consideration,
+// including the ones promoted from embedded fields. See `ImplementsPtr` for
+// pointers.
 func (s *Struct) Implements(i *Interface) bool {
-	for _, m := range i.Methods() {
-		method, ok := s.methodsMap[m.Descriptor.Name()]
-		if !ok {
-			return false
+	_, methods := s.promote(false)
+	return implements(methods, i)
+}
+
+// ImplementsPtr checks if pointers to this struct implement a given interface.
+// Unlike `Implements`, methods with pointer receivers are taken into
+// consideration.
+func (s *Struct) ImplementsPtr(i *Interface) bool {
+	_, methods := s.promote(true)
+	return implements(methods, i)
+}
+
+// AllFields returns the fields of the struct, including the fields promoted
+// from embedded fields.
+//
+// Promotion follows the Go rules: a name declared at a shallower depth
+// shadows the ones declared deeper, and names declared more than once at
+// the same depth are ambiguous, so they are not promoted.
+func (s *Struct) AllFields() []*Field {
+	fields, _ := s.promote(true)
+	return fields
+}
+
+// AllMethods returns the methods of the struct, including the methods
+// promoted from embedded fields, regardless of their receivers. That is the
+// method set of a pointer to the struct. See `Struct.AllFields` for the rules
+// followed.
+func (s *Struct) AllMethods() []*TypeMethod {
+	_, methods := s.promote(true)
+	return methods
+}
+
+// promotedType is a type reached while walking the embedded fields.
+type promotedType struct {
+	t Type
+	// indirect is set when the type is reached through a pointer. In that
+	// case, methods with pointer receivers are promoted.
+	indirect bool
+}
+
+// promote walks the embedded fields of the struct, depth by depth, collecting
+// the fields and methods accessible from it. If pointer is false, methods
+// with pointer receivers are only included when reached through an embedded
+// pointer.
+func (s *Struct) promote(pointer bool) ([]*Field, []*TypeMethod) {
+	var (
+		fields  []*Field
+		methods []*TypeMethod
+	)
+
+	// found keeps the names found at shallower depths, they shadow the ones
+	// found deeper.
+	found := make(map[string]bool)
+	seen := make(map[Type]bool)
+	current := []promotedType{{t: s, indirect: pointer}}
+	for len(current) > 0 {
+		var (
+			names        []string
+			fieldsDepth  = make(map[string][]*Field)
+			methodsDepth = make(map[string][]*TypeMethod)
+			indirects    = make(map[*TypeMethod]bool)
+			next         []promotedType
+		)
+		addName := func(name string) {
+			if len(fieldsDepth[name]) == 0 && len(methodsDepth[name]) == 0 {
+				names = append(names, name)
+			}
 		}
-		if !method.Descriptor.Compatible(m.Descriptor) {
-			return false
+
+		for _, pt := range current {
+			for _, m := range pt.t.Methods() {
+				name := m.Descriptor.Name()
+				addName(name)
+				methodsDepth[name] = append(methodsDepth[name], m)
+				indirects[m] = pt.indirect
+			}
+			for _, f := range structFields(pt.t) {
+				if f.Name == "_" {
+					continue
+				}
+				addName(f.Name)
+				fieldsDepth[f.Name] = append(fieldsDepth[f.Name], f)
+
+				if !f.Embedded {
+					continue
+				}
+				if et := embeddedType(f.RefType); et != nil && !seen[et] {
+					_, isPointer := f.RefType.(*StarRefType)
+					next = append(next, promotedType{
+						t:        et,
+						indirect: pt.indirect || isPointer,
+					})
+				}
+			}
+		}
+		for _, pt := range current {
+			seen[pt.t] = true
+		}
+
+		for _, name := range names {
+			if found[name] {
+				continue
+			}
+			found[name] = true
+			// If the name appears more than once, it is ambiguous at this depth
+			// and is not promoted.
+			switch {
+			case len(fieldsDepth[name]) == 1 && len(methodsDepth[name]) == 0:
+				fields = append(fields, fieldsDepth[name][0])
+			case len(methodsDepth[name]) == 1 && len(fieldsDepth[name]) == 0:
+				m := methodsDepth[name][0]
+				if !m.Descriptor.PointerReceiver || indirects[m] {
+					methods = append(methods, m)
+				}
+			}
 		}
+		current = next
+	}
+	return fields, methods
+}
+
+// structFields returns the fields of a Type whose underlying type is a
+// struct.
+func structFields(t Type) []*Field {
+	switch tt := t.(type) {
+	case *Struct:
+		return tt.Fields
+	case *NamedType:
+		if tt.Underlying == nil {
+			return nil
+		}
+		if s, ok := Unalias(tt.Underlying).Type().(*Struct); ok {
+			return s.Fields
+		}
+	case *Alias:
+		return structFields(tt.Aliased())
+	}
+	return nil
+}
+
+// embeddedType returns the Type of an embedded field, ignoring the pointer.
+// It returns nil if the type is not resolved.
+func embeddedType(ref RefType) Type {
+	if star, ok := ref.(*StarRefType); ok {
+		ref = star.RefType
+	}
+	ref = Unalias(ref)
+	if ref == nil {
+		return nil
 	}
-	return true
+	return ref.Type()
 }
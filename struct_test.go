@@ -36,6 +36,82 @@ var _ = Describe("Struct", func() {
 		})
 	})
 
+	Describe("Embedded fields", func() {
+		var pkg *myasthurts.Package
+
+		BeforeEach(func() {
+			env, err := myasthurts.NewEnvironment()
+			Expect(err).ToNot(HaveOccurred())
+
+			// Parses the dependency so its types are resolved.
+			_, err = env.Parse("sync")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(env.ParseFile(newDataPackageContext(env), "data/models22.sample.go")).To(Succeed())
+
+			var ok bool
+			pkg, ok = env.PackageByImportPath("data")
+			Expect(ok).To(BeTrue())
+		})
+
+		fieldNames := func(fields []*myasthurts.Field) []string {
+			names := make([]string, len(fields))
+			for i, f := range fields {
+				names[i] = f.Name
+			}
+			return names
+		}
+
+		methodNames := func(methods []*myasthurts.TypeMethod) []string {
+			names := make([]string, len(methods))
+			for i, m := range methods {
+				names[i] = m.Descriptor.Name()
+			}
+			return names
+		}
+
+		It("should parse embedded fields", func() {
+			entity, ok := pkg.StructByName("Entity")
+			Expect(ok).To(BeTrue())
+			Expect(entity.Fields).To(HaveLen(5))
+			Expect(entity.Fields[0].Name).To(Equal("Base"))
+			Expect(entity.Fields[0].Embedded).To(BeTrue())
+			Expect(entity.Fields[1].Name).To(Equal("Audit"))
+			Expect(entity.Fields[1].Embedded).To(BeTrue())
+			Expect(entity.Fields[2].Name).To(Equal("Mutex"))
+			Expect(entity.Fields[2].Embedded).To(BeTrue())
+			Expect(entity.Fields[3].Name).To(Equal("Named"))
+			Expect(entity.Fields[3].Embedded).To(BeTrue())
+			Expect(entity.Fields[4].Name).To(Equal("Title"))
+			Expect(entity.Fields[4].Embedded).To(BeFalse())
+		})
+
+		It("should promote fields from embedded fields", func() {
+			entity, ok := pkg.StructByName("Entity")
+			Expect(ok).To(BeTrue())
+
+			fields := entity.AllFields()
+			names := fieldNames(fields)
+			Expect(names[:7]).To(Equal([]string{"Base", "Audit", "Mutex", "Named", "Title", "ID", "UpdatedAt"}))
+			// CreatedAt is ambiguous, it is declared by Base and Audit.
+			Expect(names).ToNot(ContainElement("CreatedAt"))
+
+			// Title from Base is shadowed.
+			Expect(fields[4]).To(Equal(entity.Fields[4]))
+			base, _ := pkg.StructByName("Base")
+			Expect(fields[5]).To(Equal(base.Fields[0]))
+		})
+
+		It("should promote methods from embedded fields", func() {
+			entity, ok := pkg.StructByName("Entity")
+			Expect(ok).To(BeTrue())
+			Expect(entity.Methods()).To(BeEmpty())
+
+			names := methodNames(entity.AllMethods())
+			Expect(names).To(ContainElements("Identifier", "Touch", "Lock", "Unlock", "Name"))
+		})
+	})
+
 	Describe("Implements", func() {
 		It("should find struct implements an interface", func() {
 			env, err := myasthurts.NewEnvironment()
@@ -56,7 +132,9 @@ var _ = Describe("Struct", func() {
 			Expect(pkg.Interfaces[1].Name()).To(Equal("HasAge"))
 			Expect(pkg.Interfaces[2].Name()).To(Equal("HasNameWrong"))
 
-			Expect(s.Implements(pkg.Interfaces[0])).To(BeTrue())
+			Expect(s.ImplementsPtr(pkg.Interfaces[0])).To(BeTrue())
+			// Methods are declared with pointer receivers.
+			Expect(s.Implements(pkg.Interfaces[0])).To(BeFalse())
 		})
 
 		It("should not recognize the interface with missing methods", func() {
@@ -71,7 +149,7 @@ var _ = Describe("Struct", func() {
 			s, ok := pkg.StructByName("InterfaceUser")
 			Expect(ok).To(BeTrue())
 
-			Expect(s.Implements(pkg.Interfaces[1])).To(BeFalse())
+			Expect(s.ImplementsPtr(pkg.Interfaces[1])).To(BeFalse())
 		})
 
 		It("should not recognize the interface with incompatible methods", func() {
@@ -86,7 +164,29 @@ var _ = Describe("Struct", func() {
 			s, ok := pkg.StructByName("InterfaceUser")
 			Expect(ok).To(BeTrue())
 
-			Expect(s.Implements(pkg.Interfaces[2])).To(BeFalse())
+			Expect(s.ImplementsPtr(pkg.Interfaces[2])).To(BeFalse())
+		})
+
+		It("should take into consideration methods of embedded interfaces", func() {
+			env, err := myasthurts.NewEnvironment()
+			Expect(err).ToNot(HaveOccurred())
+			env.Config.Resolve = true
+
+			Expect(env.ParseFile(newDataPackageContext(env), "data/models23.sample.go")).To(Succeed())
+
+			pkg, ok := env.PackageByImportPath("data")
+			Expect(ok).To(BeTrue())
+
+			resource, ok := pkg.InterfaceByName("Resource")
+			Expect(ok).To(BeTrue())
+
+			file, ok := pkg.StructByName("File")
+			Expect(ok).To(BeTrue())
+			Expect(file.ImplementsPtr(resource)).To(BeTrue())
+
+			partial, ok := pkg.StructByName("Partial")
+			Expect(ok).To(BeTrue())
+			Expect(partial.ImplementsPtr(resource)).To(BeFalse())
 		})
 	})
 })
@@ -0,0 +1,245 @@
+package myasthurts
+
+import (
+	"go/ast"
+	"go/build"
+	"go/constant"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// sourceImporter is a types.Importer that type checks the imported packages
+// from their sources. The packages are found by Environment.Import, so the
+// same rules for finding packages are used.
+type sourceImporter struct {
+	env      *Environment
+	fset     *token.FileSet
+	packages map[string]*types.Package
+	// tests are the packages checked with their test files, those are only
+	// seen by their external test packages.
+	tests map[string]*types.Package
+	// mu serializes type checking, the packages are shared by all checks.
+	mu sync.Mutex
+}
+
+// sourceImporter returns the importer shared by the packages type checked
+// in the environment.
+func (env *Environment) sourceImporter() *sourceImporter {
+	env.importerOnce.Do(func() {
+		env.importer = newSourceImporter(env)
+	})
+	return env.importer
+}
+
+func newSourceImporter(env *Environment) *sourceImporter {
+	return &sourceImporter{
+		env:      env,
+		fset:     token.NewFileSet(),
+		packages: make(map[string]*types.Package),
+		tests:    make(map[string]*types.Package),
+	}
+}
+
+// xtestImporter is the importer of an external test package, it imports the
+// package under test checked with its test files.
+type xtestImporter struct {
+	*sourceImporter
+	forTest string
+}
+
+func (imp *xtestImporter) Import(importPath string) (*types.Package, error) {
+	if pkg, ok := imp.tests[importPath]; ok && importPath == imp.forTest {
+		return pkg, nil
+	}
+	return imp.sourceImporter.Import(importPath)
+}
+
+func (imp *sourceImporter) Import(importPath string) (*types.Package, error) {
+	if importPath == "unsafe" {
+		return types.Unsafe, nil
+	}
+	if pkg, ok := imp.packages[importPath]; ok {
+		if !pkg.Complete() {
+			return nil, errors.Errorf("import cycle through %s", importPath)
+		}
+		return pkg, nil
+	}
+
+	buildPkg, err := imp.env.Import(importPath)
+	if err != nil {
+		return nil, err
+	}
+	files, err := imp.parseFiles(buildPkg)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := types.NewPackage(importPath, buildPkg.Name)
+	imp.packages[importPath] = pkg
+	config := imp.config(func(error) {})
+	config.IgnoreFuncBodies = true
+	// Errors on dependencies (Ex: files that depend on cgo) must not prevent
+	// the declarations of the package from being known.
+	_ = types.NewChecker(config, imp.fset, pkg, nil).Files(files)
+	pkg.MarkComplete()
+	return pkg, nil
+}
+
+func (imp *sourceImporter) config(errorHandler func(error)) *types.Config {
+	return &types.Config{
+		Importer:    imp,
+		FakeImportC: true,
+		Error:       errorHandler,
+	}
+}
+
+// parseFiles parses the Go files of the package.
+func (imp *sourceImporter) parseFiles(buildPkg *build.Package) ([]*ast.File, error) {
+	files := make([]*ast.File, 0, len(buildPkg.GoFiles))
+	for _, fileName := range buildPkg.GoFiles {
+		file, err := parser.ParseFile(imp.fset, path.Join(buildPkg.Dir, fileName), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// typeCheckPackage type checks the given files of the package, already
+// parsed by preparePackage. The errors found on the package are reported, the
+// ones on its dependencies are ignored.
+func (env *Environment) typeCheckPackage(pkgCtx *ParsePackageContext, filePaths []string) (*types.Info, error) {
+	imp := env.sourceImporter()
+	imp.mu.Lock()
+	defer imp.mu.Unlock()
+
+	astFiles := make([]*ast.File, 0, len(filePaths))
+	for _, filePath := range filePaths {
+		file, _, err := pkgCtx.parsed.file(filePath)
+		if err != nil {
+			return nil, err
+		}
+		astFiles = append(astFiles, file)
+	}
+
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	var firstErr error
+	config := imp.config(func(err error) {
+		if firstErr == nil {
+			firstErr = err
+		}
+	})
+	config.IgnoreFuncBodies = true
+	if forTest := pkgCtx.Package.ForTest; forTest != nil {
+		config.Importer = &xtestImporter{imp, forTest.ImportPath}
+	}
+	checked, _ := config.Check(pkgCtx.Package.ImportPath, imp.fset, astFiles, info)
+	if firstErr != nil {
+		return nil, errors.Wrapf(firstErr, "type checking %s", pkgCtx.Package.ImportPath)
+	}
+	if pkgCtx.tests {
+		// The external test package sees the declarations of the test files
+		// of the package under test. Ex: `export_test.go` files.
+		imp.tests[pkgCtx.Package.ImportPath] = checked
+	}
+	return info, nil
+}
+
+// typeNameOf returns the type name the identifier refers to, as found by the
+// type checker. It returns nil if the file was not type checked.
+func (ctx *ParseFileContext) typeNameOf(ident *ast.Ident) *types.TypeName {
+	if ctx.info == nil {
+		return nil
+	}
+	typeName, _ := ctx.info.Uses[ident].(*types.TypeName)
+	return typeName
+}
+
+// typesRefType returns the RefType of a type name found by the type checker.
+// Type parameters, local types and the types of the package being parsed are
+// not handled, those are found in the context.
+func (ctx *ParseFileContext) typesRefType(typeName *types.TypeName) (RefType, bool) {
+	if typeName == nil {
+		return nil, false
+	}
+	if _, ok := typeName.Type().(*types.TypeParam); ok {
+		return nil, false
+	}
+	// Predeclared types. Ex: int, error
+	if typeName.Pkg() == nil {
+		if ctx.Env.BuiltIn == nil {
+			return nil, false
+		}
+		return ctx.Env.BuiltIn.RefTypeByName(typeName.Name())
+	}
+	if typeName.Parent() != typeName.Pkg().Scope() || typeName.Pkg().Path() == ctx.Package.ImportPath {
+		return nil, false
+	}
+	pkg, ok := ctx.Env.PackageByImportPath(typeName.Pkg().Path())
+	if !ok {
+		return nil, false
+	}
+	refType, _ := pkg.EnsureRefType(typeName.Name())
+	return refType, true
+}
+
+// typesConstant sets the value and the type of the constant from the type
+// checker. It returns false if the constant was not type checked.
+func (ctx *ParseFileContext) typesConstant(c *Constant, ident *ast.Ident) bool {
+	if ctx.info == nil {
+		return false
+	}
+	obj, ok := ctx.info.Defs[ident].(*types.Const)
+	if !ok || obj.Val().Kind() == constant.Unknown {
+		return false
+	}
+	c.Value = obj.Val()
+	if c.typed { // The type was given by the declaration.
+		return true
+	}
+
+	switch t := obj.Type().(type) {
+	case *types.Basic:
+		if t.Info()&types.IsUntyped != 0 {
+			c.RefType = defaultConstRefType(ctx, c.Value, t.Kind() == types.UntypedRune)
+			return true
+		}
+		typeName, _ := types.Universe.Lookup(t.Name()).(*types.TypeName)
+		if refType, ok := ctx.typesRefType(typeName); ok {
+			c.RefType, c.typed = refType, true
+		}
+	// Named types and aliases. Ex: time.Duration
+	case interface{ Obj() *types.TypeName }:
+		refType, ok := ctx.typesRefType(t.Obj())
+		if !ok { // Types of the package being parsed.
+			refType, ok = ctx.GetRefType(t.Obj().Name())
+		}
+		if ok {
+			c.RefType, c.typed = refType, true
+		}
+	}
+	return true
+}
+
+// typesArrayLen returns the length of the array as found by the type
+// checker.
+func (ctx *ParseFileContext) typesArrayLen(lenExpr ast.Expr) (int64, bool) {
+	if ctx.info == nil {
+		return 0, false
+	}
+	tv, ok := ctx.info.Types[lenExpr]
+	if !ok || tv.Value == nil {
+		return 0, false
+	}
+	return constant.Int64Val(constant.ToInt(tv.Value))
+}
@@ -0,0 +1,108 @@
+package myasthurts_test
+
+import (
+	"go/constant"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	myasthurts "github.com/jamillosantos/go-my-ast-hurts"
+)
+
+var _ = Describe("Type checking", func() {
+	var env *myasthurts.Environment
+
+	BeforeEach(func() {
+		var err error
+		env, err = myasthurts.NewEnvironment()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should not evaluate unsafe.Sizeof without type checking", func() {
+		pkg, err := env.ParseDir("./data/typecheck/valid")
+		Expect(err).ToNot(HaveOccurred())
+
+		wordSize, ok := pkg.ConstantByName("WordSize")
+		Expect(ok).To(BeTrue())
+		Expect(wordSize.Value.Kind()).To(Equal(constant.Unknown))
+
+		clock, ok := pkg.StructByName("Clock")
+		Expect(ok).To(BeTrue())
+		Expect(clock.Fields[1].RefType.(*myasthurts.ArrayRefType).Len).To(BeEquivalentTo(-1))
+	})
+
+	When("type checking is enabled", func() {
+		BeforeEach(func() {
+			env.Config.TypeCheck = true
+		})
+
+		It("should populate the same model", func() {
+			pkg, err := env.ParseDir("./data/typecheck/valid")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pkg.Explored).To(BeTrue())
+			Expect(pkg.Files).To(HaveLen(1))
+
+			clock, ok := pkg.StructByName("Clock")
+			Expect(ok).To(BeTrue())
+			Expect(clock.Fields).To(HaveLen(2))
+			Expect(clock.Fields[0].RefType.Name()).To(Equal("Time"))
+			Expect(clock.Fields[0].RefType.Pkg().ImportPath).To(Equal("time"))
+			Expect(clock.Fields[0].Position.Start.Line).To(Equal(15))
+		})
+
+		It("should evaluate constants using the type checker", func() {
+			pkg, err := env.ParseDir("./data/typecheck/valid")
+			Expect(err).ToNot(HaveOccurred())
+
+			timeout, ok := pkg.ConstantByName("Timeout")
+			Expect(ok).To(BeTrue())
+			Expect(timeout.Value.ExactString()).To(Equal("2000000000"))
+			Expect(timeout.RefType.Name()).To(Equal("Duration"))
+			Expect(timeout.RefType.Pkg().ImportPath).To(Equal("time"))
+
+			wordSize, ok := pkg.ConstantByName("WordSize")
+			Expect(ok).To(BeTrue())
+			Expect(wordSize.Value.ExactString()).To(Equal("8"))
+			Expect(wordSize.RefType.Name()).To(Equal("uintptr"))
+
+			name, ok := pkg.ConstantByName("Name")
+			Expect(ok).To(BeTrue())
+			Expect(constant.StringVal(name.Value)).To(Equal("valid"))
+			Expect(name.RefType.Name()).To(Equal("string"))
+
+			letter, ok := pkg.ConstantByName("Letter")
+			Expect(ok).To(BeTrue())
+			Expect(letter.RefType.Name()).To(Equal("rune"))
+		})
+
+		It("should evaluate the array lengths using the type checker", func() {
+			pkg, err := env.ParseDir("./data/typecheck/valid")
+			Expect(err).ToNot(HaveOccurred())
+
+			clock, ok := pkg.StructByName("Clock")
+			Expect(ok).To(BeTrue())
+			buffer, ok := clock.Fields[1].RefType.(*myasthurts.ArrayRefType)
+			Expect(ok).To(BeTrue())
+			Expect(buffer.Len).To(BeEquivalentTo(16))
+		})
+
+		It("should parse the builtin package when type checking from the start", func() {
+			env, err := myasthurts.NewEnvironmentWithConfig(myasthurts.EnvConfig{TypeCheck: true})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(env.BuiltIn.Explored).To(BeTrue())
+
+			pkg, err := env.ParseDir("./data/typecheck/valid")
+			Expect(err).ToNot(HaveOccurred())
+			wordSize, ok := pkg.ConstantByName("WordSize")
+			Expect(ok).To(BeTrue())
+			Expect(wordSize.Value.ExactString()).To(Equal("8"))
+		})
+
+		It("should report type errors", func() {
+			_, err := env.ParseDir("./data/typecheck/invalid")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("type checking"))
+			Expect(err.Error()).To(ContainSubstring("undefined: Missing"))
+		})
+	})
+})
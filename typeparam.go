@@ -0,0 +1,80 @@
+package myasthurts
+
+// TypeParam represents a type parameter declared by a generic type or
+// function. Ex: the `T` in `type Set[T comparable] map[T]struct{}`.
+type TypeParam struct {
+	BaseType
+	Constraint RefType
+}
+
+// NewTypeParam creates a new TypeParam with no constraint defined.
+func NewTypeParam(pkg *Package, name string) *TypeParam {
+	return &TypeParam{
+		BaseType: *NewBaseType(pkg, name),
+	}
+}
+
+// UnionTerm is a single term of an Union. Ex: `~int` in `~int | ~string`.
+type UnionTerm struct {
+	// Tilde is set when the term is an approximation element (`~T`).
+	Tilde   bool
+	RefType RefType
+}
+
+// Union represents the type elements used in constraints. Ex:
+// `~int | ~string`. A single approximation term (`~int`) is represented as an
+// Union with one term.
+type Union struct {
+	pkg   *Package
+	Terms []*UnionTerm
+}
+
+// NewUnion return a new empty Union.
+func NewUnion(pkg *Package) *Union {
+	return &Union{
+		pkg:   pkg,
+		Terms: make([]*UnionTerm, 0, 2),
+	}
+}
+
+func (u *Union) Package() *Package {
+	return u.pkg
+}
+
+func (u *Union) Name() string {
+	return ""
+}
+
+func (u *Union) Methods() []*TypeMethod {
+	return nil
+}
+
+func (u *Union) AddMethod(method *TypeMethod) {}
+
+// typeParamsOf returns the type parameters declared by the given Type, if
+// it is a generic declaration.
+func typeParamsOf(t Type) []*TypeParam {
+	switch tt := t.(type) {
+	case *Struct:
+		return tt.TypeParams
+	case *Interface:
+		return tt.TypeParams
+	case *NamedType:
+		return tt.TypeParams
+	case *MethodDescriptor:
+		return tt.TypeParams
+	}
+	return nil
+}
+
+// bindRecvTypeParams sets the constraints of the type parameters of a method
+// declared before its generic receiver type, which were not known when the
+// method was parsed (see parseRecvTypeParams).
+func bindRecvTypeParams(method *MethodDescriptor, recv Type) {
+	declared := typeParamsOf(recv)
+	for i, tp := range method.TypeParams {
+		if i < len(declared) && tp.Constraint == nil {
+			tp.Constraint = declared[i].Constraint
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package myasthurts_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	myasthurts "github.com/jamillosantos/go-my-ast-hurts"
+)
+
+var _ = Describe("TypeParam", func() {
+	Describe("Parse", func() {
+		It("should parse the type parameters of a struct", func() {
+			env, err := myasthurts.NewEnvironment()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(env.ParseFile(newDataPackageContext(env), "data/models16.sample.go")).To(Succeed())
+
+			pkg, ok := env.PackageByImportPath("data")
+			Expect(ok).To(BeTrue())
+
+			set, ok := pkg.StructByName("Set")
+			Expect(ok).To(BeTrue())
+			Expect(set.TypeParams).To(HaveLen(1))
+			Expect(set.TypeParams[0].Name()).To(Equal("T"))
+			Expect(set.TypeParams[0].Constraint.Name()).To(Equal("comparable"))
+
+			Expect(set.Fields).To(HaveLen(1))
+			mapType, ok := set.Fields[0].RefType.Type().(*myasthurts.MapType)
+			Expect(ok).To(BeTrue())
+			Expect(mapType.Key.Type()).To(Equal(set.TypeParams[0]))
+
+			pair, ok := pkg.StructByName("Pair")
+			Expect(ok).To(BeTrue())
+			Expect(pair.TypeParams).To(HaveLen(2))
+			Expect(pair.TypeParams[0].Name()).To(Equal("K"))
+			Expect(pair.TypeParams[1].Name()).To(Equal("V"))
+			Expect(pair.TypeParams[1].Constraint.Name()).To(Equal("any"))
+			Expect(pair.Fields[0].RefType.Type()).To(Equal(pair.TypeParams[0]))
+			Expect(pair.Fields[1].RefType.Type()).To(Equal(pair.TypeParams[1]))
+
+			// Type parameters must not leak into the package.
+			_, ok = pkg.RefTypeByName("T")
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should parse generic instantiations", func() {
+			env, err := myasthurts.NewEnvironment()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(env.ParseFile(newDataPackageContext(env), "data/models16.sample.go")).To(Succeed())
+
+			pkg, ok := env.PackageByImportPath("data")
+			Expect(ok).To(BeTrue())
+
+			catalog, ok := pkg.StructByName("Catalog")
+			Expect(ok).To(BeTrue())
+			Expect(catalog.Fields).To(HaveLen(2))
+
+			names, ok := catalog.Fields[0].RefType.(*myasthurts.InstanceRefType)
+			Expect(ok).To(BeTrue())
+			Expect(names.Name()).To(Equal("Set"))
+			Expect(names.TypeArgs).To(HaveLen(1))
+			Expect(names.TypeArgs[0].Name()).To(Equal("string"))
+			set, _ := pkg.StructByName("Set")
+			Expect(names.Type()).To(Equal(set))
+
+			entry, ok := catalog.Fields[1].RefType.(*myasthurts.InstanceRefType)
+			Expect(ok).To(BeTrue())
+			Expect(entry.Name()).To(Equal("Pair"))
+			Expect(entry.TypeArgs).To(HaveLen(2))
+			Expect(entry.TypeArgs[0].Name()).To(Equal("string"))
+			Expect(entry.TypeArgs[1].Name()).To(Equal("int64"))
+		})
+
+		It("should parse methods of generic receivers", func() {
+			env, err := myasthurts.NewEnvironment()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(env.ParseFile(newDataPackageContext(env), "data/models16.sample.go")).To(Succeed())
+
+			pkg, ok := env.PackageByImportPath("data")
+			Expect(ok).To(BeTrue())
+
+			set, ok := pkg.StructByName("Set")
+			Expect(ok).To(BeTrue())
+			Expect(set.MethodsMap()).To(HaveKey("Add"))
+
+			add := set.MethodsMap()["Add"].Descriptor
+			Expect(add.TypeParams).To(HaveLen(1))
+			Expect(add.TypeParams[0].Name()).To(Equal("T"))
+			Expect(add.TypeParams[0].Constraint.Name()).To(Equal("comparable"))
+			Expect(add.Arguments).To(HaveLen(1))
+			Expect(add.Arguments[0].Type.Type()).To(Equal(add.TypeParams[0]))
+		})
+
+		It("should parse generic functions and constraint interfaces", func() {
+			env, err := myasthurts.NewEnvironment()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(env.ParseFile(newDataPackageContext(env), "data/models16.sample.go")).To(Succeed())
+
+			pkg, ok := env.PackageByImportPath("data")
+			Expect(ok).To(BeTrue())
+
+			number, ok := pkg.InterfaceByName("Number")
+			Expect(ok).To(BeTrue())
+			Expect(number.Unions).To(HaveLen(1))
+			Expect(number.Unions[0].Terms).To(HaveLen(3))
+			Expect(number.Unions[0].Terms[0].Tilde).To(BeTrue())
+			Expect(number.Unions[0].Terms[0].RefType.Name()).To(Equal("int"))
+			Expect(number.Unions[0].Terms[1].Tilde).To(BeTrue())
+			Expect(number.Unions[0].Terms[1].RefType.Name()).To(Equal("int64"))
+			Expect(number.Unions[0].Terms[2].Tilde).To(BeFalse())
+			Expect(number.Unions[0].Terms[2].RefType.Name()).To(Equal("float64"))
+
+			sum, ok := pkg.MethodByName("Sum")
+			Expect(ok).To(BeTrue())
+			Expect(sum.TypeParams).To(HaveLen(1))
+			Expect(sum.TypeParams[0].Name()).To(Equal("N"))
+			Expect(sum.TypeParams[0].Constraint.Name()).To(Equal("Number"))
+			Expect(sum.Arguments).To(HaveLen(1))
+			Expect(sum.Arguments[0].Type.Type()).To(Equal(sum.TypeParams[0]))
+			Expect(sum.Result).To(HaveLen(1))
+			Expect(sum.Result[0].Type.Type()).To(Equal(sum.TypeParams[0]))
+		})
+
+		It("should bind the constraints of methods declared before their types", func() {
+			env, err := myasthurts.NewEnvironment()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(env.ParseFile(newDataPackageContext(env), "data/models35.sample.go")).To(Succeed())
+
+			pkg, ok := env.PackageByImportPath("data")
+			Expect(ok).To(BeTrue())
+
+			list, ok := pkg.StructByName("List")
+			Expect(ok).To(BeTrue())
+			Expect(list.MethodsMap()).To(HaveKey("Push"))
+			push := list.MethodsMap()["Push"].Descriptor
+			Expect(push.TypeParams).To(HaveLen(1))
+			Expect(push.TypeParams[0].Constraint).ToNot(BeNil())
+			Expect(push.TypeParams[0].Constraint.Name()).To(Equal("Ordered"))
+		})
+
+		It("should parse the embedded types that are not interfaces as unions", func() {
+			env, err := myasthurts.NewEnvironment()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(env.ParseFile(newDataPackageContext(env), "data/models35.sample.go")).To(Succeed())
+
+			pkg, ok := env.PackageByImportPath("data")
+			Expect(ok).To(BeTrue())
+
+			integer, ok := pkg.InterfaceByName("Integer")
+			Expect(ok).To(BeTrue())
+			Expect(integer.Embeds).To(BeEmpty())
+			Expect(integer.Unions).To(HaveLen(1))
+			Expect(integer.Unions[0].Terms).To(HaveLen(1))
+			Expect(integer.Unions[0].Terms[0].Tilde).To(BeFalse())
+			Expect(integer.Unions[0].Terms[0].RefType.Name()).To(Equal("int"))
+
+			// Celsius is declared after the interface.
+			temperature, ok := pkg.InterfaceByName("Temperature")
+			Expect(ok).To(BeTrue())
+			Expect(temperature.Embeds).To(BeEmpty())
+			Expect(temperature.Unions).To(HaveLen(1))
+			Expect(temperature.Unions[0].Terms[0].RefType.Name()).To(Equal("Celsius"))
+
+			ordered, ok := pkg.InterfaceByName("Ordered")
+			Expect(ok).To(BeTrue())
+			Expect(ordered.Unions).To(HaveLen(1))
+			Expect(ordered.Unions[0].Terms).To(HaveLen(2))
+			Expect(ordered.Embeds).To(HaveLen(2))
+			Expect(ordered.Embeds[0].Name()).To(Equal("Temperature"))
+			Expect(ordered.Embeds[1].Name()).To(Equal("Stringer"))
+			Expect(ordered.MethodsMap()).To(HaveKey("String"))
+		})
+
+		It("should parse packages using generics", func() {
+			env, err := myasthurts.NewEnvironment()
+			Expect(err).ToNot(HaveOccurred())
+
+			pkg, err := env.Parse("go/types")
+			Expect(err).ToNot(HaveOccurred())
+			_, ok := pkg.StructByName("Checker")
+			Expect(ok).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,230 @@
+package myasthurts
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Qualifier returns the name used to qualify types of the given package when
+// they are printed by TypeString. An empty name means the types are printed
+// unqualified. Ex: `Time` instead of `time.Time`.
+type Qualifier func(pkg *Package) string
+
+// RelativeTo returns a Qualifier that prints the types of `pkg` unqualified
+// and the types of other packages qualified by their names. `aliases` maps
+// import paths to the names used to import them. Ex: `{"github.com/pkg/errors":
+// "pkgerrors"}`.
+func RelativeTo(pkg *Package, aliases map[string]string) Qualifier {
+	return func(other *Package) string {
+		if other == pkg || (pkg != nil && other.ImportPath == pkg.ImportPath) {
+			return ""
+		}
+		if alias, ok := aliases[other.ImportPath]; ok {
+			return alias
+		}
+		return other.Name
+	}
+}
+
+// TypeString renders the RefType using the Go syntax. Ex: `*[]time.Time` or
+// `map[string]func(context.Context) error`.
+//
+// Types from other packages are qualified by the `qualifier`. If it is nil,
+// the package names are used. Types from the builtin package and type
+// parameters are never qualified.
+func TypeString(ref RefType, qualifier Qualifier) string {
+	var sb strings.Builder
+	writeRefType(&sb, ref, qualifier)
+	return sb.String()
+}
+
+func writeRefType(sb *strings.Builder, ref RefType, qualifier Qualifier) {
+	switch r := ref.(type) {
+	case nil:
+		return
+	case *StarRefType:
+		sb.WriteString("*")
+		writeRefType(sb, r.RefType, qualifier)
+	case *SliceRefType:
+		sb.WriteString("[]")
+		writeRefType(sb, r.RefType, qualifier)
+	case *ArrayRefType:
+		sb.WriteString("[")
+		// The evaluated length is preferred because the expression may
+		// refer to constants that are not visible from the qualified
+		// package.
+		if r.Len >= 0 {
+			sb.WriteString(strconv.FormatInt(r.Len, 10))
+		} else {
+			sb.WriteString(r.LenExpr)
+		}
+		sb.WriteString("]")
+		writeRefType(sb, r.RefType, qualifier)
+	case *ChanRefType:
+		switch r.Dir {
+		case ChanSend:
+			sb.WriteString("chan<- ")
+		case ChanRecv:
+			sb.WriteString("<-chan ")
+		default:
+			sb.WriteString("chan ")
+		}
+		// `chan (<-chan T)` would be parsed as `chan<- chan T` without the
+		// parenthesis.
+		if elem, ok := r.RefType.(*ChanRefType); ok && r.Dir == ChanBoth && elem.Dir == ChanRecv {
+			sb.WriteString("(")
+			writeRefType(sb, elem, qualifier)
+			sb.WriteString(")")
+			return
+		}
+		writeRefType(sb, r.RefType, qualifier)
+	case *EllipsisRefType:
+		sb.WriteString("...")
+		writeRefType(sb, r.RefType, qualifier)
+	case *InstanceRefType:
+		writeRefType(sb, r.RefType, qualifier)
+		sb.WriteString("[")
+		writeRefTypes(sb, r.TypeArgs, qualifier)
+		sb.WriteString("]")
+	default:
+		if isStructural(ref) {
+			writeType(sb, ref.Type(), qualifier)
+			return
+		}
+		writeTypeName(sb, ref, qualifier)
+	}
+}
+
+func writeRefTypes(sb *strings.Builder, refs []RefType, qualifier Qualifier) {
+	for i, ref := range refs {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		writeRefType(sb, ref, qualifier)
+	}
+}
+
+// writeTypeName writes the qualified name of a named type.
+func writeTypeName(sb *strings.Builder, ref RefType, qualifier Qualifier) {
+	if ref == InterfaceRefType {
+		sb.WriteString("interface{}")
+		return
+	}
+	if _, ok := ref.Type().(*TypeParam); !ok {
+		if pkg := ref.Pkg(); pkg != nil && pkg.ImportPath != "builtin" {
+			name := pkg.Name
+			if qualifier != nil {
+				name = qualifier(pkg)
+			}
+			if name != "" {
+				sb.WriteString(name)
+				sb.WriteString(".")
+			}
+		}
+	}
+	sb.WriteString(ref.Name())
+}
+
+// writeType writes type literals. Ex: maps, functions, anonymous structs and
+// interfaces.
+func writeType(sb *strings.Builder, t Type, qualifier Qualifier) {
+	switch tt := t.(type) {
+	case *MapType:
+		sb.WriteString("map[")
+		writeRefType(sb, tt.Key, qualifier)
+		sb.WriteString("]")
+		writeRefType(sb, tt.Value, qualifier)
+	case *MethodDescriptor:
+		sb.WriteString("func")
+		writeSignature(sb, tt, qualifier)
+	case *Struct:
+		sb.WriteString("struct{")
+		for i, field := range tt.Fields {
+			if i > 0 {
+				sb.WriteString("; ")
+			}
+			if !field.Embedded {
+				sb.WriteString(field.Name)
+				sb.WriteString(" ")
+			}
+			writeRefType(sb, field.RefType, qualifier)
+			if field.Tag.Raw != "" {
+				sb.WriteString(" ")
+				sb.WriteString(quoteTag(field.Tag.Raw))
+			}
+		}
+		sb.WriteString("}")
+	case *Interface:
+		sb.WriteString("interface{")
+		first := true
+		separate := func() {
+			if !first {
+				sb.WriteString("; ")
+			}
+			first = false
+		}
+		for _, embed := range tt.Embeds {
+			separate()
+			writeRefType(sb, embed, qualifier)
+		}
+		for _, union := range tt.Unions {
+			separate()
+			writeType(sb, union, qualifier)
+		}
+		// Only the methods declared by the interface. The methods of the
+		// embedded interfaces are represented by the embeds.
+		for _, method := range tt.BaseType.Methods() {
+			separate()
+			sb.WriteString(method.Name)
+			writeSignature(sb, method.Descriptor, qualifier)
+		}
+		sb.WriteString("}")
+	case *Union:
+		for i, term := range tt.Terms {
+			if i > 0 {
+				sb.WriteString(" | ")
+			}
+			if term.Tilde {
+				sb.WriteString("~")
+			}
+			writeRefType(sb, term.RefType, qualifier)
+		}
+	}
+}
+
+// writeSignature writes the arguments and results of a function. Names are
+// omitted. Ex: `(int, string) (bool, error)`.
+func writeSignature(sb *strings.Builder, md *MethodDescriptor, qualifier Qualifier) {
+	sb.WriteString("(")
+	for i, arg := range md.Arguments {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		writeRefType(sb, arg.Type, qualifier)
+	}
+	sb.WriteString(")")
+
+	switch len(md.Result) {
+	case 0:
+	case 1:
+		sb.WriteString(" ")
+		writeRefType(sb, md.Result[0].Type, qualifier)
+	default:
+		sb.WriteString(" (")
+		for i, r := range md.Result {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			writeRefType(sb, r.Type, qualifier)
+		}
+		sb.WriteString(")")
+	}
+}
+
+// quoteTag quotes the raw struct tag, preferring the raw string literal.
+func quoteTag(tag string) string {
+	if strings.Contains(tag, "`") {
+		return strconv.Quote(tag)
+	}
+	return "`" + tag + "`"
+}
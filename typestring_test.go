@@ -0,0 +1,70 @@
+package myasthurts_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	myasthurts "github.com/jamillosantos/go-my-ast-hurts"
+)
+
+var _ = Describe("TypeString", func() {
+	var (
+		pkg   *myasthurts.Package
+		event *myasthurts.Struct
+	)
+
+	BeforeEach(func() {
+		env, err := myasthurts.NewEnvironment()
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = env.Parse("time")
+		Expect(err).ToNot(HaveOccurred())
+		_, err = env.Parse("context")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(env.ParseFile(newDataPackageContext(env), "data/models28.sample.go")).To(Succeed())
+
+		var ok bool
+		pkg, ok = env.PackageByImportPath("data")
+		Expect(ok).To(BeTrue())
+		event, ok = pkg.StructByName("Event")
+		Expect(ok).To(BeTrue())
+	})
+
+	DescribeTable("should render the types using the Go syntax",
+		func(field int, expected string) {
+			Expect(myasthurts.TypeString(event.Fields[field].RefType, myasthurts.RelativeTo(pkg, nil))).To(Equal(expected))
+		},
+		Entry("pointers and slices", 0, "*[]time.Time"),
+		Entry("maps and functions", 1, "map[string]func(context.Context, ...string) (bool, error)"),
+		Entry("arrays", 2, "[4]byte"),
+		Entry("channels", 3, "chan (<-chan *Event)"),
+		Entry("generic instantiations", 4, "List[time.Duration]"),
+		Entry("anonymous structs", 5, "struct{Name string `json:\"name\"`; time.Location}"),
+		Entry("anonymous interfaces", 6, "interface{Visit(*Event) error}"),
+		Entry("empty interfaces", 7, "interface{}"),
+		Entry("functions returning functions", 8, "func() func() int"),
+	)
+
+	It("should qualify the types of the package relative to another one", func() {
+		timePkg := event.Fields[0].RefType.Pkg()
+		Expect(myasthurts.TypeString(event.Fields[0].RefType, myasthurts.RelativeTo(timePkg, nil))).To(Equal("*[]Time"))
+		Expect(myasthurts.TypeString(event.Fields[3].RefType, myasthurts.RelativeTo(timePkg, nil))).To(Equal("chan (<-chan *models.Event)"))
+	})
+
+	It("should use the given import aliases", func() {
+		qualifier := myasthurts.RelativeTo(pkg, map[string]string{
+			"time": "stdtime",
+		})
+		Expect(myasthurts.TypeString(event.Fields[0].RefType, qualifier)).To(Equal("*[]stdtime.Time"))
+	})
+
+	It("should use the package names without a qualifier", func() {
+		Expect(myasthurts.TypeString(event.Fields[4].RefType, nil)).To(Equal("models.List[time.Duration]"))
+	})
+
+	It("should not qualify type parameters", func() {
+		list, ok := pkg.StructByName("List")
+		Expect(ok).To(BeTrue())
+		Expect(myasthurts.TypeString(list.Fields[0].RefType, nil)).To(Equal("[]T"))
+	})
+})
@@ -0,0 +1,145 @@
+package myasthurts
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"runtime"
+	"sync"
+)
+
+// parsedFiles keeps the ASTs of the files of a package, parsed ahead of
+// building the models.
+type parsedFiles struct {
+	fset  *token.FileSet
+	files map[string]*ast.File
+	// errs keeps the errors per file, so they are only reported if the file
+	// is used. Ex: files skipped by a ListenerBeforeFile.
+	errs map[string]error
+}
+
+// file returns the AST of the file, if it was parsed ahead.
+func (pf *parsedFiles) file(filePath string) (*ast.File, bool, error) {
+	if pf == nil {
+		return nil, false, nil
+	}
+	if err, ok := pf.errs[filePath]; ok {
+		return nil, true, err
+	}
+	file, ok := pf.files[filePath]
+	return file, ok, nil
+}
+
+// workers returns the number of goroutines used for parsing (see
+// EnvConfig.Workers).
+func (env *Environment) workers() int {
+	if env.Config.Workers > 0 {
+		return env.Config.Workers
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// parallel calls fn for each of the n items, using up to `workers`
+// goroutines. It returns when all calls are done.
+func parallel(workers, n int, fn func(i int)) {
+	if workers > n {
+		workers = n
+	}
+	if workers <= 1 {
+		for i := 0; i < n; i++ {
+			fn(i)
+		}
+		return
+	}
+
+	items := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range items {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		items <- i
+	}
+	close(items)
+	wg.Wait()
+}
+
+// parseFiles parses the files in parallel.
+func (env *Environment) parseFiles(fset *token.FileSet, filePaths []string) *parsedFiles {
+	files := make([]*ast.File, len(filePaths))
+	errs := make([]error, len(filePaths))
+	parallel(env.workers(), len(filePaths), func(i int) {
+		files[i], errs[i] = parser.ParseFile(fset, filePaths[i], nil, parser.ParseComments)
+	})
+
+	parsed := &parsedFiles{
+		fset:  fset,
+		files: make(map[string]*ast.File, len(filePaths)),
+		errs:  make(map[string]error),
+	}
+	for i, filePath := range filePaths {
+		if errs[i] != nil {
+			parsed.errs[filePath] = errs[i]
+			continue
+		}
+		parsed.files[filePath] = files[i]
+	}
+	return parsed
+}
+
+// preparePackage lists the files of the package and parses them ahead of
+// building the models. If EnvConfig.TypeCheck is set, the package is type
+// checked as well.
+//
+// The models are not changed, so many packages can be prepared concurrently.
+func (env *Environment) preparePackage(pkgCtx *ParsePackageContext) error {
+	if pkgCtx.parsed != nil {
+		return nil
+	}
+
+	goFiles := env.packageFiles(pkgCtx, pkgCtx.BuildPackage)
+	files := make([]string, len(goFiles))
+	for i, file := range goFiles {
+		files[i] = path.Join(pkgCtx.Package.RealPath, file)
+	}
+	// The files type checked are the ones of the build context, even when
+	// parsing for many platforms.
+	checkFiles := files
+	if len(env.Config.Platforms) > 0 {
+		var err error
+		if files, pkgCtx.platforms, err = env.platformFiles(pkgCtx); err != nil {
+			return err
+		}
+	}
+
+	// The builtin package redeclares the predeclared identifiers, it is not
+	// valid Go for the type checker.
+	typeCheck := env.Config.TypeCheck && pkgCtx.Package.ImportPath != "builtin"
+	fset := token.NewFileSet()
+	if typeCheck {
+		fset = env.sourceImporter().fset
+	}
+	parseFiles := append([]string(nil), files...)
+	for _, filePath := range checkFiles {
+		if _, ok := pkgCtx.platforms[filePath]; !ok && len(env.Config.Platforms) > 0 {
+			parseFiles = append(parseFiles, filePath)
+		}
+	}
+	pkgCtx.files, pkgCtx.parsed = files, env.parseFiles(fset, parseFiles)
+
+	if typeCheck {
+		info, err := env.typeCheckPackage(pkgCtx, checkFiles)
+		if err != nil {
+			return err
+		}
+		pkgCtx.info = info
+	}
+	return nil
+}
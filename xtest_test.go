@@ -0,0 +1,118 @@
+package myasthurts_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	myasthurts "github.com/jamillosantos/go-my-ast-hurts"
+)
+
+var _ = Describe("Test files", func() {
+	const importPath = "github.com/jamillosantos/go-my-ast-hurts/data/tests"
+
+	var env *myasthurts.Environment
+
+	BeforeEach(func() {
+		var err error
+		env, err = myasthurts.NewEnvironment()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should ignore the test files by default", func() {
+		pkg, err := env.ParseDir("./data/tests")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(pkg.Files).To(HaveLen(1))
+		Expect(pkg.Files[0].Test).To(BeFalse())
+		Expect(pkg.Variables).To(BeEmpty())
+		Expect(pkg.XTest).To(BeNil())
+	})
+
+	When("the test files are included", func() {
+		var pkg *myasthurts.Package
+
+		BeforeEach(func() {
+			env.Config.IncludeTests = true
+
+			var err error
+			pkg, err = env.ParseDir("./data/tests")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should merge the test files into the package", func() {
+			Expect(pkg.Files).To(HaveLen(3))
+			Expect(pkg.Files[0].FileName).To(HaveSuffix("calc.go"))
+			Expect(pkg.Files[0].Test).To(BeFalse())
+			Expect(pkg.Files[1].FileName).To(HaveSuffix("calc_test.go"))
+			Expect(pkg.Files[1].Test).To(BeTrue())
+			Expect(pkg.Files[2].FileName).To(HaveSuffix("export_test.go"))
+			Expect(pkg.Files[2].Test).To(BeTrue())
+
+			fixture, ok := pkg.StructByName("fixture")
+			Expect(ok).To(BeTrue())
+			Expect(fixture.Fields).To(HaveLen(3))
+
+			Expect(pkg.Variables).To(HaveLen(2))
+			Expect(pkg.Variables[0].Name).To(Equal("fixtures"))
+			Expect(pkg.Variables[1].Name).To(Equal("Sum"))
+
+			_, ok = pkg.MethodByName("TestAdd")
+			Expect(ok).To(BeTrue())
+		})
+
+		It("should parse the external test package", func() {
+			xtest := pkg.XTest
+			Expect(xtest).ToNot(BeNil())
+			Expect(xtest.Name).To(Equal("calc_test"))
+			Expect(xtest.ImportPath).To(Equal(importPath + "_test"))
+			Expect(xtest.ForTest).To(Equal(pkg))
+			Expect(xtest.Explored).To(BeTrue())
+			Expect(xtest.Files).To(HaveLen(1))
+			Expect(xtest.Files[0].Test).To(BeTrue())
+
+			found, ok := env.PackageByImportPath(importPath + "_test")
+			Expect(ok).To(BeTrue())
+			Expect(found).To(Equal(xtest))
+			found, ok = env.PackageByImportPath(importPath)
+			Expect(ok).To(BeTrue())
+			Expect(found).To(Equal(pkg))
+		})
+
+		It("should link the external test package to the package under test", func() {
+			example, ok := pkg.XTest.StructByName("Example")
+			Expect(ok).To(BeTrue())
+			Expect(example.Fields).To(HaveLen(1))
+			Expect(example.Fields[0].RefType.Pkg()).To(Equal(pkg))
+			Expect(example.Fields[0].RefType.Type()).To(BeAssignableToTypeOf(&myasthurts.Struct{}))
+
+			_, ok = pkg.XTest.MethodByName("ExampleAdd")
+			Expect(ok).To(BeTrue())
+		})
+
+		It("should type check the test files", func() {
+			env, err := myasthurts.NewEnvironment()
+			Expect(err).ToNot(HaveOccurred())
+			env.Config.IncludeTests = true
+			env.Config.TypeCheck = true
+
+			pkg, err := env.ParseDir("./data/tests")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pkg.Files).To(HaveLen(3))
+			Expect(pkg.XTest.Files).To(HaveLen(1))
+		})
+
+		It("should not expose the test files to the packages importing the package under test", func() {
+			env, err := myasthurts.NewEnvironment()
+			Expect(err).ToNot(HaveOccurred())
+			env.Config.IncludeTests = true
+			env.Config.TypeCheck = true
+
+			_, err = env.ParseDir("./data/tests")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = env.ParseDir("./data/typecheck/testonly")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("undefined: calc.Sum"))
+		})
+	})
+})